@@ -1,12 +1,30 @@
 package groupme
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
 // A polymorphic list of Message attachment types.
 const (
-	ImageAttachment    = "image"    // contains: Type, URL
-	LocationAttachment = "location" // contains: Type, Name, Lat, Lng
-	SplitAttachment    = "split"    // contains: Type, Token
-	EmojiAttachment    = "emoji"    // contains: Type, Placeholder, Charmap
-	MentionsAttachment = "mentions" // contains: Type, UserIDs, Loci
+	ImageAttachment       = "image"        // contains: Type, URL
+	LocationAttachment    = "location"     // contains: Type, Name, Lat, Lng
+	SplitAttachment       = "split"        // contains: Type, Token
+	EmojiAttachment       = "emoji"        // contains: Type, Placeholder, Charmap
+	MentionsAttachment    = "mentions"     // contains: Type, UserIDs, Loci
+	EventAttachment       = "event"        // contains: Type, EventID, View, Name
+	LinkedImageAttachment = "linked_image" // contains: Type, URL
+	ReplyAttachment       = "reply"        // contains: Type, ReplyID, BaseReplyID
+	VideoAttachment       = "video"        // contains: Type, URL, PreviewURL, Status
+	PollAttachment        = "poll"         // contains: Type, PollID
+)
+
+// Views of a shared calendar event.
+const (
+	EventViewFull   = "full"
+	EventViewLinked = "linked"
 )
 
 // Attachment is a GroupMe attachment.
@@ -14,22 +32,246 @@ type Attachment struct {
 	// shared
 	Type string `json:"type"`
 
-	// Image
-	URL string `json:"url"`
+	// Image, LinkedImage, Video
+	URL string `json:"url,omitempty"`
+
+	// Video
+	PreviewURL string `json:"preview_url,omitempty"`
+	Status     string `json:"status,omitempty"`
 
-	// Location
-	Name string `json:"name"`
-	Lat  string `json:"lat"`
-	Lng  string `json:"lng"`
+	// Location, Event
+	Name string `json:"name,omitempty"`
+	Lat  string `json:"lat,omitempty"`
+	Lng  string `json:"lng,omitempty"`
 
 	// Split
-	Token string `json:"token"`
+	Token string `json:"token,omitempty"`
 
 	// Emoji
-	Placeholder string  `json:"placeholder"`
-	Charmap     [][]int `json:"charmap"`
+	Placeholder string  `json:"placeholder,omitempty"`
+	Charmap     [][]int `json:"charmap,omitempty"`
 
 	// Mentions
-	UserIDs []string `json:"user_ids"`
-	Loci    [][]int  `json:"loci"`
+	UserIDs []string `json:"user_ids,omitempty"`
+	Loci    [][]int  `json:"loci,omitempty"`
+
+	// Event
+	EventID string `json:"event_id,omitempty"`
+	View    string `json:"view,omitempty"`
+
+	// Reply
+	ReplyID     string `json:"reply_id,omitempty"`
+	BaseReplyID string `json:"base_reply_id,omitempty"`
+
+	// Poll
+	PollID string `json:"poll_id,omitempty"`
+
+	// Raw is the original JSON of an attachment of a type this package does
+	// not model. It is marshaled back in place of the other fields, so such
+	// attachments survive being relayed.
+	Raw json.RawMessage `json:"-"`
+
+	// image to upload before sending, see ImageFile
+	upload *imageUpload
+}
+
+// knownAttachmentTypes are the attachment types Attachment models.
+var knownAttachmentTypes = map[string]bool{
+	ImageAttachment:       true,
+	LocationAttachment:    true,
+	SplitAttachment:       true,
+	EmojiAttachment:       true,
+	MentionsAttachment:    true,
+	EventAttachment:       true,
+	LinkedImageAttachment: true,
+	ReplyAttachment:       true,
+	VideoAttachment:       true,
+	PollAttachment:        true,
+}
+
+// MarshalJSON encodes an Attachment. Attachments of unknown types decoded
+// from GroupMe are encoded from Raw, so they are semantically identical to
+// what was received, though encoding/json compacts their whitespace and may
+// escape characters such as '<' and '&'.
+func (a Attachment) MarshalJSON() ([]byte, error) {
+	if a.Raw != nil && !knownAttachmentTypes[a.Type] {
+		return a.Raw, nil
+	}
+
+	type attachment Attachment
+	return json.Marshal(attachment(a))
+}
+
+// UnmarshalJSON decodes an Attachment, accepting a location's lat and lng as
+// either strings or numbers. The JSON of attachments of unknown types is kept
+// in Raw.
+func (a *Attachment) UnmarshalJSON(b []byte) error {
+	type attachment Attachment
+	aux := struct {
+		*attachment
+		Lat json.RawMessage `json:"lat"`
+		Lng json.RawMessage `json:"lng"`
+	}{attachment: (*attachment)(a)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if a.Lat, err = stringOrNumber(aux.Lat); err != nil {
+		return fmt.Errorf("groupme: attachment lat: %w", err)
+	}
+	if a.Lng, err = stringOrNumber(aux.Lng); err != nil {
+		return fmt.Errorf("groupme: attachment lng: %w", err)
+	}
+
+	if !knownAttachmentTypes[a.Type] {
+		a.Raw = append(json.RawMessage(nil), b...)
+	}
+
+	return nil
+}
+
+// stringOrNumber decodes a JSON string or number as a string.
+func stringOrNumber(raw json.RawMessage) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+
+	if raw[0] == '"' {
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+type imageUpload struct {
+	r           io.Reader
+	contentType string
+}
+
+// ImageFile returns an image Attachment for a local image, which
+// CreateRichMessage uploads to GroupMe's image service before sending.
+// contentType must be the image's MIME type: "image/jpeg", "image/png" or
+// "image/gif".
+func ImageFile(r io.Reader, contentType string) Attachment {
+	return Attachment{
+		Type:   ImageAttachment,
+		upload: &imageUpload{r: r, contentType: contentType},
+	}
+}
+
+// NewImageAttachment returns an Attachment for an image hosted by GroupMe's
+// image service.
+func NewImageAttachment(url string) Attachment {
+	return Attachment{
+		Type: ImageAttachment,
+		URL:  url,
+	}
+}
+
+// NewLocationAttachment returns an Attachment for a named location.
+func NewLocationAttachment(name, lat, lng string) Attachment {
+	return Attachment{
+		Type: LocationAttachment,
+		Name: name,
+		Lat:  lat,
+		Lng:  lng,
+	}
+}
+
+// NewReplyAttachment returns an Attachment marking a message as a reply to
+// the message replyID.
+func NewReplyAttachment(replyID string) Attachment {
+	return Attachment{
+		Type:        ReplyAttachment,
+		ReplyID:     replyID,
+		BaseReplyID: replyID,
+	}
+}
+
+// EventShareAttachment is a shared calendar event.
+type EventShareAttachment struct {
+	EventID string
+	View    string
+	Name    string
+}
+
+// NewEventShareAttachment returns an Attachment sharing a calendar event.
+func NewEventShareAttachment(eventID, view, name string) Attachment {
+	return Attachment{
+		Type:    EventAttachment,
+		EventID: eventID,
+		View:    view,
+		Name:    name,
+	}
+}
+
+// AsEventShare returns the Attachment as an EventShareAttachment if it is one.
+func (a *Attachment) AsEventShare() (EventShareAttachment, bool) {
+	if a.Type != EventAttachment {
+		return EventShareAttachment{}, false
+	}
+
+	return EventShareAttachment{
+		EventID: a.EventID,
+		View:    a.View,
+		Name:    a.Name,
+	}, true
+}
+
+// LinkedImage is a link preview generated by GroupMe for a URL in a message.
+type LinkedImage struct {
+	URL string
+}
+
+// AsLinkedImage returns the Attachment as a LinkedImage if it is one.
+func (a *Attachment) AsLinkedImage() (LinkedImage, bool) {
+	if a.Type != LinkedImageAttachment {
+		return LinkedImage{}, false
+	}
+
+	return LinkedImage{URL: a.URL}, true
+}
+
+// Video is a video hosted by GroupMe's video service. PreviewURL is available
+// while the video is still being transcoded.
+type Video struct {
+	URL        string
+	PreviewURL string
+	Status     string
+}
+
+// AsVideo returns the Attachment as a Video if it is one.
+func (a *Attachment) AsVideo() (Video, bool) {
+	if a.Type != VideoAttachment {
+		return Video{}, false
+	}
+
+	return Video{
+		URL:        a.URL,
+		PreviewURL: a.PreviewURL,
+		Status:     a.Status,
+	}, true
+}
+
+// PollRef is a reference to a poll posted in a conversation. Use GetPoll for
+// the poll itself.
+type PollRef struct {
+	PollID string
+}
+
+// AsPoll returns the Attachment as a PollRef if it is one.
+func (a *Attachment) AsPoll() (PollRef, bool) {
+	if a.Type != PollAttachment {
+		return PollRef{}, false
+	}
+
+	return PollRef{PollID: a.PollID}, true
 }
@@ -0,0 +1,170 @@
+package groupme
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAttachmentDecode(t *testing.T) {
+	tests := []struct {
+		json string
+		want Attachment
+	}{
+		{
+			`{"type":"image","url":"https://i.groupme.com/1"}`,
+			Attachment{Type: ImageAttachment, URL: "https://i.groupme.com/1"},
+		},
+		{
+			`{"type":"location","name":"Station 1","lat":41.8486,"lng":-71.8853}`,
+			Attachment{Type: LocationAttachment, Name: "Station 1", Lat: "41.8486", Lng: "-71.8853"},
+		},
+		{
+			`{"type":"location","name":"Station 1","lat":"41.8486","lng":"-71.8853"}`,
+			Attachment{Type: LocationAttachment, Name: "Station 1", Lat: "41.8486", Lng: "-71.8853"},
+		},
+		{
+			`{"type":"split","token":"SPLIT_TOKEN"}`,
+			Attachment{Type: SplitAttachment, Token: "SPLIT_TOKEN"},
+		},
+		{
+			`{"type":"emoji","placeholder":"�","charmap":[[1,42],[1,43]]}`,
+			Attachment{Type: EmojiAttachment, Placeholder: "�", Charmap: [][]int{{1, 42}, {1, 43}}},
+		},
+		{
+			`{"type":"mentions","user_ids":["1","2"],"loci":[[0,4],[5,3]]}`,
+			Attachment{Type: MentionsAttachment, UserIDs: []string{"1", "2"}, Loci: [][]int{{0, 4}, {5, 3}}},
+		},
+		{
+			`{"type":"event","event_id":"e1","view":"full","name":"Drill night"}`,
+			Attachment{Type: EventAttachment, EventID: "e1", View: EventViewFull, Name: "Drill night"},
+		},
+		{
+			`{"type":"linked_image","url":"https://example.com/p.png"}`,
+			Attachment{Type: LinkedImageAttachment, URL: "https://example.com/p.png"},
+		},
+		{
+			`{"type":"reply","reply_id":"1","base_reply_id":"0"}`,
+			Attachment{Type: ReplyAttachment, ReplyID: "1", BaseReplyID: "0"},
+		},
+		{
+			`{"type":"video","url":"https://v.groupme.com/1.mp4","preview_url":"https://v.groupme.com/1.jpg","status":"processing"}`,
+			Attachment{Type: VideoAttachment, URL: "https://v.groupme.com/1.mp4", PreviewURL: "https://v.groupme.com/1.jpg", Status: "processing"},
+		},
+		{
+			`{"type":"poll","poll_id":"98765"}`,
+			Attachment{Type: PollAttachment, PollID: "98765"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want.Type, func(t *testing.T) {
+			var got Attachment
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal =\n%+v\nwant\n%+v", got, tt.want)
+			}
+
+			// round trip
+			buf, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var again Attachment
+			if err := json.Unmarshal(buf, &again); err != nil {
+				t.Fatalf("Unmarshal %s: %v", buf, err)
+			}
+			if !reflect.DeepEqual(again, tt.want) {
+				t.Errorf("after round trip =\n%+v\nwant\n%+v", again, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentDecodeBadLocation(t *testing.T) {
+	var a Attachment
+	if err := json.Unmarshal([]byte(`{"type":"location","lat":true}`), &a); err == nil {
+		t.Fatal("Unmarshal with a boolean lat: want error")
+	}
+}
+
+func TestAttachmentAccessors(t *testing.T) {
+	video := Attachment{Type: VideoAttachment, URL: "u", PreviewURL: "p", Status: "complete"}
+	if v, ok := video.AsVideo(); !ok || v != (Video{URL: "u", PreviewURL: "p", Status: "complete"}) {
+		t.Errorf("AsVideo = %+v, %t", v, ok)
+	}
+	if _, ok := video.AsPoll(); ok {
+		t.Error("AsPoll on a video: ok = true")
+	}
+
+	event := NewEventShareAttachment("e1", EventViewLinked, "Drill")
+	if e, ok := event.AsEventShare(); !ok || e != (EventShareAttachment{EventID: "e1", View: EventViewLinked, Name: "Drill"}) {
+		t.Errorf("AsEventShare = %+v, %t", e, ok)
+	}
+}
+
+func TestAttachmentMarshalOmitsZeroFields(t *testing.T) {
+	tests := []struct {
+		attachment Attachment
+		want       string
+	}{
+		{NewImageAttachment("https://i.groupme.com/1"), `{"type":"image","url":"https://i.groupme.com/1"}`},
+		{NewLocationAttachment("Station 1", "41.8", "-71.8"), `{"type":"location","name":"Station 1","lat":"41.8","lng":"-71.8"}`},
+		{NewReplyAttachment("9"), `{"type":"reply","reply_id":"9","base_reply_id":"9"}`},
+		{NewEveryoneMention(), `{"type":"mentions","user_ids":["0"],"loci":[[0,0]]}`},
+	}
+
+	for _, tt := range tests {
+		buf, err := json.Marshal(tt.attachment)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		if string(buf) != tt.want {
+			t.Errorf("Marshal =\n%s\nwant\n%s", buf, tt.want)
+		}
+	}
+}
+
+func TestUnknownAttachmentRoundTrip(t *testing.T) {
+	const raw = `{ "type": "sticker", "pack_id": 5, "url": "https://example.com/s?a=1&b=<2>", "frames": [{"ms": 40}, {"ms": 80}] }`
+
+	var a Attachment
+	if err := json.Unmarshal([]byte(raw), &a); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if a.Type != "sticker" || a.Raw == nil {
+		t.Fatalf("Attachment = %+v, want type sticker with Raw kept", a)
+	}
+
+	buf, err := json.Marshal(Message{Attachments: []Attachment{a}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var relayed struct {
+		Attachments []interface{} `json:"attachments"`
+	}
+	if err := json.Unmarshal(buf, &relayed); err != nil {
+		t.Fatalf("Unmarshal %s: %v", buf, err)
+	}
+
+	var want interface{}
+	json.Unmarshal([]byte(raw), &want)
+	if len(relayed.Attachments) != 1 || !reflect.DeepEqual(relayed.Attachments[0], want) {
+		t.Errorf("relayed attachment = %v, want %v", relayed.Attachments, want)
+	}
+}
+
+func TestKnownAttachmentIgnoresRaw(t *testing.T) {
+	a := NewImageAttachment("https://i.groupme.com/1")
+	a.Raw = json.RawMessage(`{"type":"image","url":"stale"}`)
+
+	buf, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"image","url":"https://i.groupme.com/1"}`; string(buf) != want {
+		t.Errorf("Marshal = %s, want %s", buf, want)
+	}
+}
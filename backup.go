@@ -0,0 +1,183 @@
+package groupme
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"sync"
+)
+
+// backupConcurrency is the number of concurrent downloads made by BackupGroup.
+const backupConcurrency = 4
+
+// BackupManifest is the manifest.json of a BackupGroup archive.
+type BackupManifest struct {
+	GroupID string `json:"group_id"`
+
+	// Messages maps message IDs to the files of their attachments.
+	Messages map[string][]string `json:"messages"`
+
+	// Files maps files in the archive to the URLs they were downloaded from.
+	Files map[string]string `json:"files"`
+
+	// Failed maps attachment URLs that could not be downloaded to the error.
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// BackupGroup writes a zip archive of a group's whole history to w: every
+// message, newest first, in messages.jsonl; the images and videos attached to
+// them under media/, each URL downloaded once however often it is attached;
+// and a manifest.json (see BackupManifest) mapping messages to their files.
+// Linked images are hosted elsewhere and are kept only as URLs in the messages.
+// Media that cannot be downloaded, such as expired videos, is recorded in the
+// manifest rather than failing the backup. The archive is incomplete if an
+// error is returned.
+func (c *Client) BackupGroup(ctx context.Context, groupID string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	manifest := BackupManifest{
+		GroupID:  groupID,
+		Messages: map[string][]string{},
+		Files:    map[string]string{},
+		Failed:   map[string]string{},
+	}
+
+	// write messages, collecting media URLs
+	jsonl, err := zw.Create("messages.jsonl")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(jsonl)
+	var urls []string
+	files := map[string]string{}
+	it := c.IterateMessages(groupID)
+	for it.Next(ctx) {
+		message := it.Message()
+		if err := enc.Encode(message); err != nil {
+			return err
+		}
+
+		for _, a := range message.Attachments {
+			if a.URL == "" || (a.Type != ImageAttachment && a.Type != VideoAttachment) {
+				continue
+			}
+			name, ok := files[a.URL]
+			if !ok {
+				name = fmt.Sprintf("media/%d", len(urls)+1)
+				files[a.URL] = name
+				urls = append(urls, a.URL)
+			}
+			manifest.Messages[message.ID] = append(manifest.Messages[message.ID], name)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	// download media concurrently, writing it out as it arrives
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		writeErr error
+	)
+	sem := make(chan struct{}, backupConcurrency)
+	for _, url := range urls {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			body, contentType, err := c.DownloadAttachment(ctx, url)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if writeErr != nil {
+				return
+			}
+			if err != nil {
+				manifest.Failed[url] = err.Error()
+				return
+			}
+
+			name := files[url] + mediaExtension(url, contentType)
+			fw, err := zw.Create(name)
+			if err == nil {
+				_, err = fw.Write(body)
+			}
+			if err != nil {
+				writeErr = err
+				return
+			}
+			manifest.Files[name] = url
+		}(url)
+	}
+	wg.Wait()
+	if writeErr != nil {
+		return writeErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// refer to media by its final name
+	renamed := map[string]string{}
+	for name, url := range manifest.Files {
+		renamed[files[url]] = name
+	}
+	for id, names := range manifest.Messages {
+		var downloaded []string
+		for _, name := range names {
+			if final, ok := renamed[name]; ok {
+				downloaded = append(downloaded, final)
+			}
+		}
+		manifest.Messages[id] = downloaded
+	}
+
+	mw, err := zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(mw).Encode(manifest); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// mediaExtensions are the file extensions of the media types GroupMe serves.
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"video/mp4":  ".mp4",
+}
+
+// mediaExtension returns a file extension for downloaded media, from its
+// content type or else its URL. Types GroupMe serves get fixed extensions;
+// others are looked up in the system's MIME tables.
+func mediaExtension(url, contentType string) string {
+	if contentType != "" {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+			if ext, ok := mediaExtensions[mediaType]; ok {
+				return ext
+			}
+			if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+				return exts[0]
+			}
+		}
+	}
+	return path.Ext(url)
+}
@@ -0,0 +1,111 @@
+package groupme
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBackupGroup(t *testing.T) {
+	h := newFakeHistory(3, time.Unix(1700000000, 0))
+	h.messages[0].Attachments = []Attachment{NewImageAttachment("https://i.groupme.com/a")}
+	h.messages[1].Attachments = []Attachment{
+		{Type: LinkedImageAttachment, URL: "https://example.com/p.png"},
+		{Type: VideoAttachment, URL: "https://v.groupme.com/expired.mp4"},
+	}
+	h.messages[2].Attachments = []Attachment{NewImageAttachment("https://i.groupme.com/a")}
+
+	c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Host {
+		case "api.groupme.test":
+			h.ServeHTTP(w, r)
+		case "i.groupme.com":
+			w.Header().Set("Content-Type", "image/png")
+			io.WriteString(w, "PNGDATA")
+		case "v.groupme.com":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("request to %s%s", r.Host, r.URL.Path)
+		}
+	}))
+
+	var buf bytes.Buffer
+	if err := c.BackupGroup(context.Background(), "g1", &buf); err != nil {
+		t.Fatalf("BackupGroup: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("reading archive: %v", err)
+	}
+
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+
+	var ids []string
+	sc := bufio.NewScanner(bytes.NewReader(files["messages.jsonl"]))
+	for sc.Scan() {
+		var m Message
+		if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+			t.Fatalf("messages.jsonl: %v", err)
+		}
+		ids = append(ids, m.ID)
+	}
+	if want := []string{"3", "2", "1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("messages = %v, want %v", ids, want)
+	}
+
+	if got := string(files["media/1.png"]); got != "PNGDATA" {
+		t.Errorf("media/1.png = %q, want PNGDATA", got)
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("manifest.json: %v", err)
+	}
+	// the image is downloaded once, the linked image not at all
+	want := BackupManifest{
+		GroupID:  "g1",
+		Messages: map[string][]string{"1": {"media/1.png"}, "2": nil, "3": {"media/1.png"}},
+		Files:    map[string]string{"media/1.png": "https://i.groupme.com/a"},
+		Failed:   map[string]string{"https://v.groupme.com/expired.mp4": "404 Not Found"},
+	}
+	if !reflect.DeepEqual(manifest, want) {
+		t.Errorf("manifest =\n%+v\nwant\n%+v", manifest, want)
+	}
+}
+
+func TestMediaExtension(t *testing.T) {
+	tests := []struct {
+		url, contentType string
+		want             string
+	}{
+		{"https://i.groupme.com/1", "image/jpeg", ".jpg"},
+		{"https://i.groupme.com/1", "IMAGE/JPEG; charset=binary", ".jpg"},
+		{"https://i.groupme.com/1", "image/png", ".png"},
+		{"https://i.groupme.com/1", "image/gif", ".gif"},
+		{"https://v.groupme.com/1", "video/mp4", ".mp4"},
+		{"https://v.groupme.com/1.mov", "", ".mov"},
+		{"https://i.groupme.com/1.webp", "not a type", ".webp"},
+		{"https://i.groupme.com/1", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := mediaExtension(tt.url, tt.contentType); got != tt.want {
+			t.Errorf("mediaExtension(%q, %q) = %q, want %q", tt.url, tt.contentType, got, tt.want)
+		}
+	}
+}
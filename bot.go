@@ -2,18 +2,31 @@ package groupme
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Bot is a GroupMe Bot.
 type Bot struct {
-	BaseURL   string
-	ID        string
-	GroupID   string
-	GroupName string
-	AvatarURL string
+	BaseURL   string `json:"-"`
+	ID        string `json:"bot_id"`
+	GroupID   string `json:"group_id"`
+	GroupName string `json:"group_name"`
+	AvatarURL string `json:"avatar_url"`
+
+	Name        string `json:"name"`
+	CallbackURL string `json:"callback_url"`
+
+	// HTTPClient sends the bot's posts. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client `json:"-"`
+
+	// Tracer, if set, traces the bot's posts.
+	Tracer Tracer `json:"-"`
 }
 
 // BotPost is a message from a Bot.
@@ -21,6 +34,11 @@ type BotPost struct {
 	BotID       string       `json:"bot_id"`
 	Text        string       `json:"text"`
 	Attachments []Attachment `json:"attachments"`
+
+	// CreatedAt is an original timestamp for imported messages. GroupMe
+	// ignores it and stamps bot posts with the time received; see
+	// PostBotMessageAt.
+	CreatedAt int64 `json:"created_at,omitempty"`
 }
 
 // NewBot returns a new GroupMe Bot.
@@ -34,14 +52,56 @@ func NewBot(baseURL, ID, groupID, groupName, avatarURL string) Bot {
 	}
 }
 
-// Post posts a message.
+// botAttachmentTypes are the attachment types a bot is allowed to post.
+// Others, such as emoji powerups, are rejected by GroupMe.
+var botAttachmentTypes = map[string]bool{
+	ImageAttachment:    true,
+	LocationAttachment: true,
+	MentionsAttachment: true,
+	ReplyAttachment:    true,
+}
+
+// validateBotAttachments returns an error if any attachment is of a type a
+// bot is not allowed to post.
+func validateBotAttachments(attachments []Attachment) error {
+	for _, a := range attachments {
+		if !botAttachmentTypes[a.Type] {
+			return fmt.Errorf("%w: bots cannot post %q attachments", ErrInvalidMessage, a.Type)
+		}
+	}
+	return nil
+}
+
+// Post posts a message. Bots may only post image, location, mentions and
+// reply attachments.
 func (b *Bot) Post(message string, attachments []Attachment) error {
+	return b.PostWithContext(context.Background(), message, attachments)
+}
+
+// PostWithContext is Post with a context.
+func (b *Bot) PostWithContext(ctx context.Context, message string, attachments []Attachment) error {
+	if err := validateBotAttachments(attachments); err != nil {
+		return err
+	}
+
 	// generate URL for request
-	URL, err := createURL(b.BaseURL, "/bots/post", "")
+	URL, err := createURL(b.BaseURL, "", "/bots/post", "")
 	if err != nil {
 		return err
 	}
 
+	ctx, span := startSpan(ctx, b.Tracer, RequestInfo{Endpoint: "bots.post", Method: http.MethodPost, GroupID: b.GroupID})
+	status, err := b.post(ctx, URL, message, attachments)
+	span.End(RequestResult{StatusCode: status, Err: err})
+
+	return err
+}
+
+// post sends message to URL in as many posts as needed, returning the status
+// of the last response.
+func (b *Bot) post(ctx context.Context, URL, message string, attachments []Attachment) (int, error) {
+	status := 0
+
 	// chunk message down to lengths of 1000 or less
 	for _, buf := range b.getBufferedMessage(message, "\n") {
 		post := BotPost{
@@ -52,27 +112,170 @@ func (b *Bot) Post(message string, attachments []Attachment) error {
 
 		jsonStr, err := json.Marshal(post)
 		if err != nil {
-			return err
+			return status, err
 		}
 
-		req, err := http.NewRequest("POST", URL, bytes.NewBuffer(jsonStr))
+		req, err := http.NewRequestWithContext(ctx, "POST", URL, bytes.NewBuffer(jsonStr))
+		if err != nil {
+			return status, err
+		}
 		req.Header.Set("Content-Type", "application/json")
 
-		client := &http.Client{}
+		client := b.HTTPClient
+		if client == nil {
+			client = http.DefaultClient
+		}
 		resp, err := client.Do(req)
 		if err != nil {
-			return err
+			return status, err
 		}
-		defer resp.Body.Close()
+		resp.Body.Close()
+		status = resp.StatusCode
 
 		if resp.StatusCode != http.StatusAccepted {
-			return parseError(resp.StatusCode, resp.Status)
+			return status, &APIError{StatusCode: resp.StatusCode}
+		}
+	}
+
+	return status, nil
+}
+
+// PostBotMessage posts a message as a bot, split into as many posts as needed
+// to fit GroupMe's length limit. Bots may only post image, location, mentions
+// and reply attachments; others are rejected before sending.
+func (c *Client) PostBotMessage(ctx context.Context, botID, text string, attachments []Attachment) error {
+	return c.postBotMessage(ctx, botID, text, attachments, 0)
+}
+
+// PostBotMessageAt is PostBotMessage for imported messages, sending createdAt
+// as the post's created_at. GroupMe does not document created_at for bot
+// posts and ignores it, stamping messages with the time they are received,
+// so sending it is currently a no-op: a nil error means the message was
+// posted, at the time received. Imports that need the original time must
+// carry it in the text.
+func (c *Client) PostBotMessageAt(ctx context.Context, botID, text string, attachments []Attachment, createdAt time.Time) error {
+	return c.postBotMessage(ctx, botID, text, attachments, createdAt.Unix())
+}
+
+func (c *Client) postBotMessage(ctx context.Context, botID, text string, attachments []Attachment, createdAt int64) error {
+	if err := validateBotAttachments(attachments); err != nil {
+		return err
+	}
+
+	bot := Bot{ID: botID}
+	for _, buf := range bot.getBufferedMessage(text, "\n") {
+		post := BotPost{
+			BotID:       botID,
+			Text:        buf,
+			Attachments: attachments,
+			CreatedAt:   createdAt,
+		}
+
+		err := c.doRequest(ctx, "bots.post", http.MethodPost, "/bots/post", nil, post, nil)
+		if err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// ListBots retrieves the authenticated user's bots. The bots' BaseURL,
+// HTTPClient and Tracer are set so that Post can be used on them directly.
+func (c *Client) ListBots(ctx context.Context) ([]Bot, error) {
+	var bots []Bot
+	err := c.doRequest(ctx, "bots.list", http.MethodGet, "/bots", nil, nil, &bots)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := createURL(c.BaseURL, c.Version, "", "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range bots {
+		bots[i].BaseURL = baseURL
+		bots[i].HTTPClient = c.HTTPClient
+		bots[i].Tracer = c.Tracer
+	}
+
+	return bots, nil
+}
+
+// botCache caches a Client's bots for PostBotMessageByName.
+type botCache struct {
+	mu   sync.Mutex
+	bots []Bot
+}
+
+// botCacheInit guards the lazy creation of Clients' bot caches.
+var botCacheInit sync.Mutex
+
+// botCache returns the Client's bot cache, creating it for Clients that were
+// not made by NewClient.
+func (c *Client) botCache() *botCache {
+	botCacheInit.Lock()
+	defer botCacheInit.Unlock()
+
+	if c.bots == nil {
+		c.bots = &botCache{}
+	}
+	return c.bots
+}
+
+// cachedBots returns the Client's bots, listing them if they are not cached.
+func (c *Client) cachedBots(ctx context.Context) ([]Bot, error) {
+	cache := c.botCache()
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.bots == nil {
+		bots, err := c.ListBots(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// cache having no bots too
+		cache.bots = append([]Bot{}, bots...)
+	}
+
+	return cache.bots, nil
+}
+
+// InvalidateBotCache forgets the bots cached by PostBotMessageByName, so they
+// are listed again on the next call. Call it after creating, renaming or
+// destroying bots.
+func (c *Client) InvalidateBotCache() {
+	cache := c.botCache()
+	cache.mu.Lock()
+	cache.bots = nil
+	cache.mu.Unlock()
+}
+
+// PostBotMessageByName posts a message as the bot named botName in a group.
+// The Client's bots are listed once and cached; see InvalidateBotCache.
+func (c *Client) PostBotMessageByName(ctx context.Context, botName, groupID, text string, attachments []Attachment) error {
+	bots, err := c.cachedBots(ctx)
+	if err != nil {
+		return err
+	}
+
+	var candidates []string
+	for _, bot := range bots {
+		if bot.Name == botName && bot.GroupID == groupID {
+			candidates = append(candidates, bot.ID)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return fmt.Errorf("%w: %q in group %s", ErrBotNotFound, botName, groupID)
+	case 1:
+		return c.PostBotMessage(ctx, candidates[0], text, attachments)
+	default:
+		return fmt.Errorf("groupme: %d bots named %q in group %s: %s", len(candidates), botName, groupID, strings.Join(candidates, ", "))
+	}
+}
+
 // getBufferedMessage returns a list of strings no bigger than
 // what is allowed to be sent as a GroupMe Bot message (length: 1000).
 func (b *Bot) getBufferedMessage(s, sep string) []string {
@@ -0,0 +1,187 @@
+package groupme
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// botServer records the posts made to /bots/post.
+type botServer struct {
+	posts []BotPost
+}
+
+func (s *botServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/bots/post" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var post BotPost
+	if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.posts = append(s.posts, post)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func TestPostBotMessageAt(t *testing.T) {
+	s := &botServer{}
+	c := newTestClient(t, s)
+
+	createdAt := time.Unix(1500000000, 0)
+	// success is a nil error, so callers retrying on error post only once
+	if err := c.PostBotMessageAt(context.Background(), "b1", "imported", nil, createdAt); err != nil {
+		t.Fatalf("PostBotMessageAt: %v", err)
+	}
+	if len(s.posts) != 1 || s.posts[0].CreatedAt != 1500000000 || s.posts[0].Text != "imported" {
+		t.Errorf("posts = %+v", s.posts)
+	}
+}
+
+func TestPostBotMessageAtFailure(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMetaError(w, http.StatusNotFound)
+	}))
+
+	err := c.PostBotMessageAt(context.Background(), "b1", "imported", nil, time.Now())
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestPostBotMessage(t *testing.T) {
+	s := &botServer{}
+	c := newTestClient(t, s)
+
+	line := strings.Repeat("a", 600)
+	if err := c.PostBotMessage(context.Background(), "b1", line+"\n"+line, nil); err != nil {
+		t.Fatalf("PostBotMessage: %v", err)
+	}
+	if len(s.posts) != 2 || s.posts[0].Text != line || s.posts[1].Text != line || s.posts[0].CreatedAt != 0 {
+		t.Errorf("got %d posts, want the text split in two", len(s.posts))
+	}
+
+	err := c.PostBotMessage(context.Background(), "b1", "hi", []Attachment{{Type: EmojiAttachment}})
+	if !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("emoji attachment: err = %v, want ErrInvalidMessage", err)
+	}
+	if len(s.posts) != 2 {
+		t.Errorf("rejected post was sent")
+	}
+}
+
+func TestBotPostSendsNoToken(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.RawQuery != "" || r.Header.Get("X-Access-Token") != "" {
+			t.Errorf("bot post sent credentials: %s, %v", r.URL, r.Header)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(srv.Close)
+
+	// a bot posts with its ID alone
+	bot := NewBot(srv.URL, "b1", "g1", "Alerts", "")
+	if err := bot.Post("hello", nil); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+// botListServer lists bots and records the posts made by them.
+type botListServer struct {
+	botServer
+	bots  []Bot
+	lists int
+}
+
+func (s *botListServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == "/bots" {
+		s.lists++
+		writeEnvelope(w, http.StatusOK, s.bots)
+		return
+	}
+	s.botServer.ServeHTTP(w, r)
+}
+
+func TestPostBotMessageByName(t *testing.T) {
+	s := &botListServer{bots: []Bot{
+		{ID: "b1", Name: "Alerts", GroupID: "g1"},
+		{ID: "b2", Name: "Alerts", GroupID: "g2"},
+		{ID: "b3", Name: "Dup", GroupID: "g1"},
+		{ID: "b4", Name: "Dup", GroupID: "g1"},
+	}}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	// a Client made without NewClient still caches its bots
+	c := &Client{BaseURL: srv.URL, AccessToken: "test-token"}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := c.PostBotMessageByName(ctx, "Alerts", "g2", "hi", nil); err != nil {
+			t.Fatalf("PostBotMessageByName: %v", err)
+		}
+	}
+	if s.lists != 1 {
+		t.Errorf("bots listed %d times, want 1", s.lists)
+	}
+	if len(s.posts) != 3 || s.posts[0].BotID != "b2" {
+		t.Errorf("posts = %+v, want three by b2", s.posts)
+	}
+
+	err := c.PostBotMessageByName(ctx, "Nobody", "g1", "hi", nil)
+	if !errors.Is(err, ErrBotNotFound) {
+		t.Errorf("unknown name: err = %v, want ErrBotNotFound", err)
+	}
+	err = c.PostBotMessageByName(ctx, "Alerts", "g3", "hi", nil)
+	if !errors.Is(err, ErrBotNotFound) {
+		t.Errorf("unknown group: err = %v, want ErrBotNotFound", err)
+	}
+
+	err = c.PostBotMessageByName(ctx, "Dup", "g1", "hi", nil)
+	if err == nil || errors.Is(err, ErrBotNotFound) || !strings.Contains(err.Error(), "b3, b4") {
+		t.Errorf("ambiguous name: err = %v, want one naming b3 and b4", err)
+	}
+	if len(s.posts) != 3 {
+		t.Errorf("posts = %d, want no more after failures", len(s.posts))
+	}
+	if s.lists != 1 {
+		t.Errorf("bots listed %d times, want 1", s.lists)
+	}
+
+	// after invalidating, a new bot is found
+	s.bots = append(s.bots, Bot{ID: "b5", Name: "Nobody", GroupID: "g1"})
+	c.InvalidateBotCache()
+	if err := c.PostBotMessageByName(ctx, "Nobody", "g1", "hi", nil); err != nil {
+		t.Fatalf("after InvalidateBotCache: %v", err)
+	}
+	if s.lists != 2 || s.posts[len(s.posts)-1].BotID != "b5" {
+		t.Errorf("lists = %d, last post %+v; want 2 and b5", s.lists, s.posts[len(s.posts)-1])
+	}
+}
+
+func TestPostBotMessageByNameNoBots(t *testing.T) {
+	s := &botListServer{}
+	c := newTestClient(t, s)
+
+	for i := 0; i < 2; i++ {
+		if err := c.PostBotMessageByName(context.Background(), "Alerts", "g1", "hi", nil); !errors.Is(err, ErrBotNotFound) {
+			t.Fatalf("err = %v, want ErrBotNotFound", err)
+		}
+	}
+	// having no bots is cached too
+	if s.lists != 1 {
+		t.Errorf("bots listed %d times, want 1", s.lists)
+	}
+}
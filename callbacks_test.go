@@ -0,0 +1,85 @@
+package groupme
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const callbackBody = `{"attachments":[],"avatar_url":"https://i.groupme.com/123456789","created_at":1302623328,"group_id":"1234567890","id":"1234567890","name":"John","sender_id":"12345","sender_type":"user","source_guid":"GUID","system":false,"text":"Hello world ☃☃","user_id":"1234567890"}`
+
+func TestParseCallback(t *testing.T) {
+	m, err := ParseCallback(strings.NewReader(callbackBody))
+	if err != nil {
+		t.Fatalf("ParseCallback: %v", err)
+	}
+	if m.ID != "1234567890" || m.GroupID != "1234567890" || m.Text != "Hello world ☃☃" || m.SenderType != "user" || m.CreatedAt != 1302623328 {
+		t.Errorf("ParseCallback = %+v", m)
+	}
+
+	if _, err := ParseCallback(strings.NewReader(`{"id":`)); err == nil {
+		t.Error("ParseCallback with truncated JSON: want error")
+	}
+}
+
+func TestCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantCalled bool
+	}{
+		{"message", http.MethodPost, callbackBody, http.StatusOK, true},
+		{"get", http.MethodGet, "", http.StatusMethodNotAllowed, false},
+		{"malformed", http.MethodPost, "not json", http.StatusBadRequest, false},
+		{"too large", http.MethodPost, `{"text":"` + strings.Repeat("a", maxCallbackBytes) + `"}`, http.StatusRequestEntityTooLarge, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			h := CallbackHandler(func(m *Message) {
+				called = true
+			})
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, "/callback", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("handle called = %t, want %t", called, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestVerifiedCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		target     string
+		wantStatus int
+	}{
+		{"matching secret", "s3cret", "/callback?secret=s3cret", http.StatusOK},
+		{"wrong secret", "s3cret", "/callback?secret=guess", http.StatusForbidden},
+		{"missing secret", "s3cret", "/callback", http.StatusForbidden},
+		{"empty secret never verifies", "", "/callback?secret=", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := VerifiedCallbackHandler(tt.secret, CallbackHandler(func(*Message) {}))
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(callbackBody)))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package groupme
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Chat is a direct message conversation with another user.
+type Chat struct {
+	OtherUser     UserRef  `json:"other_user"`
+	LastMessage   *Message `json:"last_message"`
+	MessagesCount int      `json:"messages_count"`
+	CreatedAt     int      `json:"created_at"`
+	UpdatedAt     int      `json:"updated_at"`
+}
+
+// ListChats retrieves a page of the authenticated user's direct message
+// conversations, most recently updated first. Pages start at 1.
+func (c *Client) ListChats(ctx context.Context, page int) ([]Chat, error) {
+	// build query params
+	values := url.Values{}
+	if page > 0 {
+		values.Add("page", strconv.Itoa(page))
+	}
+
+	var chats []Chat
+	err := c.doRequest(ctx, "chats.list", http.MethodGet, "/chats", values, nil, &chats)
+	if err != nil {
+		return nil, err
+	}
+
+	return chats, nil
+}
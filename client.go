@@ -1,15 +1,466 @@
 package groupme
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
 // Client is a GroupMe API client.
 type Client struct {
 	BaseURL     string
 	AccessToken string
+
+	// Version is the API version (e.g. "v3") appended to BaseURL.
+	// If empty, BaseURL is used as is.
+	Version string
+
+	// RateLimiter, if set, paces every request. Independently of it, requests
+	// rejected as rate limited (429 or 420) are retried up to three times
+	// after the wait GroupMe asks for in Retry-After, or a second.
+	RateLimiter RateLimiter
+
+	// RetryPolicy controls retries of transient failures. The zero value
+	// disables them.
+	RetryPolicy RetryPolicy
+
+	// UserAgent is sent as the User-Agent header of every request. If
+	// empty, DefaultUserAgent is sent unless Header sets one.
+	UserAgent string
+
+	// Header holds headers added to every request. They do not replace the
+	// headers a request sets itself, such as Content-Type.
+	Header http.Header
+
+	// HTTPClient sends every request. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// DefaultTimeout bounds each request made with a context that has no
+	// deadline, including the untimed methods that use context.Background.
+	// Deadlines set by the caller always take precedence. Methods that make
+	// many requests, such as AllMessages, apply it to each request rather
+	// than to the whole operation. Zero means no timeout.
+	DefaultTimeout time.Duration
+
+	// RawMessages keeps the original JSON of each message retrieved with
+	// GetMessages and its relatives in Message.Raw, at a memory cost.
+	RawMessages bool
+
+	// TokenInHeader sends the access token in the X-Access-Token header
+	// rather than the token query parameter, keeping it out of URLs that may
+	// be logged. The image service always uses the header.
+	TokenInHeader bool
+
+	// TokenSource, if set, supplies the access token for each request in
+	// place of AccessToken. A request answered with 401 Unauthorized is
+	// retried once, with the token the source returns next, so a source can
+	// rotate an expired token when it sees it rejected.
+	TokenSource TokenSource
+
+	// StrictDecoding rejects API responses with fields the target types do
+	// not declare. It is meant for finding gaps in this package's types while
+	// developing; GroupMe adds fields without notice, so leave it off in
+	// production. Types with their own decoding, such as Attachment, are
+	// not checked.
+	StrictDecoding bool
+
+	// Logger, if set, logs every request at debug level: its endpoint,
+	// method, status code, duration and any error, including GroupMe's meta
+	// errors. Access tokens are never logged.
+	Logger *slog.Logger
+
+	// Tracer, if set, traces every API request.
+	Tracer Tracer
+
+	// Metrics, if set, observes every request.
+	Metrics Metrics
+
+	middleware []Middleware
+	bots       *botCache
+}
+
+// TokenSource supplies access tokens.
+type TokenSource interface {
+	// Token returns the access token to send with a request.
+	Token(ctx context.Context) (string, error)
+}
+
+// httpClient returns the http.Client requests are sent with.
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// setHeaders adds the Client's default headers and User-Agent to req.
+func (c *Client) setHeaders(req *http.Request) {
+	for k, v := range c.Header {
+		if _, ok := req.Header[k]; !ok {
+			req.Header[k] = append([]string(nil), v...)
+		}
+	}
+
+	switch {
+	case c.UserAgent != "":
+		req.Header.Set("User-Agent", c.UserAgent)
+	case c.Header.Get("User-Agent") == "":
+		req.Header.Set("User-Agent", DefaultUserAgent)
+	}
+}
+
+// token returns the access token to send with a request.
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.TokenSource != nil {
+		return c.TokenSource.Token(ctx)
+	}
+	return c.AccessToken, nil
+}
+
+// RequestFunc sends an HTTP request to GroupMe.
+type RequestFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RequestFunc. Implementations must call next to send the
+// request, unless they deliberately short-circuit it with their own response
+// or error.
+type Middleware func(next RequestFunc) RequestFunc
+
+// Use adds middleware around every request the Client sends, including those
+// to the image and powerup services. Middleware runs in the order it was
+// added: the first added is the outermost, seeing the request first and the
+// response last. Retries pass through the middleware again.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
 }
 
-// NewClient returns a new GroupMe API client.
-func NewClient(baseURL, accessToken string) Client {
-	return Client{
-		BaseURL:     baseURL,
+// WithMiddleware adds middleware to the Client, as with Use.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.Use(middleware...)
+	}
+}
+
+// roundTrip sends req through the Client's middleware and HTTPClient.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	send := RequestFunc(c.httpClient().Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		send = c.middleware[i](send)
+	}
+	return send(req)
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL sets the URL of the GroupMe API. A version segment at the end
+// of baseURL (as in V3BaseURL) is used as the API version.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		base, version := splitVersion(baseURL)
+		c.BaseURL = base
+		if version != "" {
+			c.Version = version
+		}
+	}
+}
+
+// WithUserAgent sets the Client's UserAgent.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithHeader adds a header sent with every request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		if c.Header == nil {
+			c.Header = http.Header{}
+		}
+		c.Header.Add(key, value)
+	}
+}
+
+// WithTimeout sets the Client's DefaultTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.DefaultTimeout = timeout
+	}
+}
+
+// WithVersion sets the API version, e.g. "v3".
+func WithVersion(version string) Option {
+	return func(c *Client) {
+		c.Version = version
+	}
+}
+
+// WithHTTPClient sets the Client's HTTPClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithRawMessages enables the Client's RawMessages setting.
+func WithRawMessages() Option {
+	return func(c *Client) {
+		c.RawMessages = true
+	}
+}
+
+// WithTokenInHeader enables the Client's TokenInHeader setting.
+func WithTokenInHeader() Option {
+	return func(c *Client) {
+		c.TokenInHeader = true
+	}
+}
+
+// WithTokenSource sets the Client's TokenSource.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) {
+		c.TokenSource = ts
+	}
+}
+
+// WithStrictDecoding enables the Client's StrictDecoding setting.
+func WithStrictDecoding() Option {
+	return func(c *Client) {
+		c.StrictDecoding = true
+	}
+}
+
+// WithMetrics sets the Client's Metrics.
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) {
+		c.Metrics = m
+	}
+}
+
+// NewClient returns a new GroupMe API client for accessToken, talking to
+// DefaultBaseURL and DefaultVersion unless configured otherwise. The
+// configuration is validated once the options are applied.
+func NewClient(accessToken string, opts ...Option) (Client, error) {
+	c := Client{
+		BaseURL:     DefaultBaseURL,
 		AccessToken: accessToken,
+		Version:     DefaultVersion,
+		RetryPolicy: DefaultRetryPolicy,
+		bots:        &botCache{},
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.AccessToken == "" && c.TokenSource == nil {
+		return Client{}, errors.New("groupme: an access token or TokenSource is required")
+	}
+	u, err := url.Parse(c.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return Client{}, fmt.Errorf("groupme: invalid base URL %q", c.BaseURL)
+	}
+	if c.Version != "" && !versionRegexp.MatchString(c.Version) {
+		return Client{}, fmt.Errorf("%w: %q", ErrInvalidVersion, c.Version)
+	}
+	if c.DefaultTimeout < 0 {
+		return Client{}, fmt.Errorf("groupme: negative timeout %s", c.DefaultTimeout)
+	}
+
+	return c, nil
+}
+
+// Do sends a request to an arbitrary GroupMe API endpoint. It is an escape
+// hatch for endpoints this package does not wrap yet: the access token is
+// added to query (which may be nil), body (if non-nil) is sent as JSON, and
+// the "response" field of the returned envelope is decoded into out (if
+// non-nil). Errors, retries and rate limiting are handled the same way as for
+// the typed methods.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body interface{}, out interface{}) error {
+	return c.doRequest(ctx, "do", method, path, query, body, out)
+}
+
+// doRequest is the shared request path behind every API call. endpoint is a
+// stable name for the call, used to label metrics.
+func (c *Client) doRequest(ctx context.Context, endpoint, method, route string, query url.Values, body interface{}, out interface{}) error {
+	info := RequestInfo{Endpoint: endpoint, Method: method, GroupID: groupIDOf(route)}
+	return c.instrument(ctx, info, func(ctx context.Context) (int, int, error) {
+		return c.sendWithRetries(ctx, endpoint, method, route, query, body, out)
+	})
+}
+
+// sendWithRetries sends a request, retrying it as the Client is configured
+// to. It returns the status of the last response and the number of retries.
+func (c *Client) sendWithRetries(ctx context.Context, endpoint, method, route string, query url.Values, body interface{}, out interface{}) (int, int, error) {
+	status, err := c.send(ctx, method, route, query, body, out)
+	tokenRetries := 0
+	if c.TokenSource != nil && errors.Is(err, ErrUnauthorized) {
+		tokenRetries++
+		status, err = c.send(ctx, method, route, query, body, out)
+	}
+
+	retries, rateLimitRetries := 0, 0
+	for {
+		var wait time.Duration
+		switch {
+		case isRateLimited(status) && rateLimitRetries < maxRateLimitRetries:
+			rateLimitRetries++
+			wait = defaultRateLimitWait
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+				wait = apiErr.RetryAfter
+			}
+			if o, ok := c.Metrics.(RateLimitObserver); ok {
+				o.ObserveRateLimit(endpoint, wait)
+			}
+		case retries+1 < c.RetryPolicy.MaxAttempts && c.RetryPolicy.retryable(method, status, err):
+			retries++
+			wait = c.RetryPolicy.delay(retries)
+		default:
+			return status, tokenRetries + retries + rateLimitRetries, err
+		}
+
+		if sleepErr := sleep(ctx, wait); sleepErr != nil {
+			return status, tokenRetries + retries + rateLimitRetries - 1, err
+		}
+		status, err = c.send(ctx, method, route, query, body, out)
+	}
+}
+
+// send waits for the RateLimiter, if any, then sends a request.
+func (c *Client) send(ctx context.Context, method, route string, query url.Values, body interface{}, out interface{}) (int, error) {
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return 0, err
+		}
+	}
+	return c.sendRequest(ctx, method, route, query, body, out)
+}
+
+// sendRequest sends a request and decodes its response, returning the HTTP
+// status code of the response, or 0 if none was received.
+func (c *Client) sendRequest(ctx context.Context, method, route string, query url.Values, body interface{}, out interface{}) (status int, err error) {
+	if _, ok := ctx.Deadline(); !ok && c.DefaultTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.DefaultTimeout)
+		defer cancel()
+	}
+
+	token, err := c.token(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// build query params
+	values := url.Values{}
+	for k, v := range query {
+		values[k] = v
+	}
+	if !c.TokenInHeader {
+		values.Set("token", token)
+	}
+	params := values.Encode()
+
+	// generate URL for request
+	URL, err := createURL(c.BaseURL, c.Version, route, params)
+	if err != nil {
+		return 0, err
+	}
+
+	// encode body
+	var reqBody io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewBuffer(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, URL, reqBody)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.setHeaders(req)
+	if c.TokenInHeader {
+		req.Header.Set("X-Access-Token", token)
+	}
+
+	// send request, read body
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return 0, err
+	}
+	if isRateLimited(resp.StatusCode) {
+		defer func() {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+			}
+		}()
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return resp.StatusCode, err
 	}
+
+	// exit early on error; only a GET can be answered with 304 Not Modified
+	if resp.StatusCode == http.StatusNotModified && method == http.MethodGet {
+		return resp.StatusCode, ErrNotModified
+	}
+
+	// some endpoints (like, unlike, ...) respond without a body
+	if len(bytes.TrimSpace(respBody)) == 0 {
+		return resp.StatusCode, checkResponse(resp.StatusCode, Meta{})
+	}
+
+	// parse response
+	var envelope struct {
+		Response json.RawMessage `json:"response"`
+		Meta     Meta            `json:"meta"`
+	}
+	err = json.Unmarshal(respBody, &envelope)
+	if err != nil {
+		// outages are served as HTML error pages
+		if resp.StatusCode >= 500 {
+			return resp.StatusCode, &APIError{StatusCode: resp.StatusCode, outage: true}
+		}
+		if !isSuccess(resp.StatusCode) {
+			return resp.StatusCode, &APIError{StatusCode: resp.StatusCode}
+		}
+		return resp.StatusCode, err
+	}
+
+	// exit early on error
+	if err := checkResponse(resp.StatusCode, envelope.Meta); err != nil {
+		return resp.StatusCode, err
+	}
+
+	if out == nil || len(envelope.Response) == 0 {
+		return resp.StatusCode, nil
+	}
+
+	return resp.StatusCode, c.decode(envelope.Response, out)
+}
+
+// decode decodes data into out, rejecting unknown fields if StrictDecoding is
+// set.
+func (c *Client) decode(data []byte, out interface{}) error {
+	if !c.StrictDecoding {
+		return json.Unmarshal(data, out)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(out)
 }
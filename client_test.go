@@ -0,0 +1,411 @@
+package groupme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client talking to an httptest server running
+// handler. Routes reach handler without an API version prefix, and retries
+// are disabled unless opts enable them.
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	opts = append([]Option{WithBaseURL(srv.URL), WithVersion(""), WithoutRetries()}, opts...)
+	c, err := NewClient("test-token", opts...)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return &c
+}
+
+// newRedirectedClient is newTestClient for code that talks to fixed hosts,
+// such as the image service: every request is sent to handler, which can tell
+// the hosts apart by r.Host.
+func newRedirectedClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts = append([]Option{
+		WithBaseURL("https://api.groupme.test"),
+		WithVersion(""),
+		WithoutRetries(),
+		WithHTTPClient(&http.Client{Transport: redirectTransport{target}}),
+	}, opts...)
+	c, err := NewClient("test-token", opts...)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return &c
+}
+
+// redirectTransport sends every request to target, keeping its Host.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Host = req.URL.Host
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// writeEnvelope writes response in GroupMe's response envelope.
+func writeEnvelope(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"response": response,
+		"meta":     Meta{Code: status},
+	})
+}
+
+// writeMetaError writes a GroupMe error response.
+func writeMetaError(w http.ResponseWriter, status int, errs ...string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"response": nil,
+		"meta":     Meta{Code: status, Errors: errs},
+	})
+}
+
+func TestEmptyBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{"204", http.StatusNoContent, nil},
+		{"200", http.StatusOK, nil},
+		{"404", http.StatusNotFound, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+
+			err := c.LikeMessage("g1", "m1")
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("LikeMessage: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			// a decode target is left alone
+			var out struct{ ID string }
+			err = c.Do(context.Background(), http.MethodPost, "/anything", nil, nil, &out)
+			if tt.wantErr == nil && (err != nil || out.ID != "") {
+				t.Errorf("Do: err = %v, out = %+v", err, out)
+			}
+		})
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"response":{"count":1,"messages":[{"id":"1","text":"hi","brand_new_field":true}]},"meta":{"code":200}}`)
+	})
+
+	lenient := newTestClient(t, handler)
+	resp, err := lenient.GetMessages("g1", "", "", "", "")
+	if err != nil || len(resp.Messages) != 1 {
+		t.Fatalf("lenient GetMessages = %+v, %v", resp, err)
+	}
+
+	strict := newTestClient(t, handler, WithStrictDecoding())
+	_, err = strict.GetMessages("g1", "", "", "", "")
+	if err == nil || !strings.Contains(err.Error(), "brand_new_field") {
+		t.Fatalf("strict GetMessages: err = %v, want an unknown field error", err)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}), WithTimeout(10*time.Millisecond))
+
+	if err := c.LikeMessage("g1", "m1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+
+	if _, err := NewClient("test-token", WithTimeout(-time.Second)); err == nil {
+		t.Error("NewClient with a negative timeout succeeded")
+	}
+}
+
+func TestWithTokenInHeader(t *testing.T) {
+	for _, tt := range pathCalls {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if strings.Contains(r.URL.String(), "test-token") || r.URL.Query().Has("token") {
+					t.Errorf("token in URL %s", r.URL)
+				}
+				if got := r.Header.Get("X-Access-Token"); got != "test-token" {
+					t.Errorf("X-Access-Token = %q, want test-token", got)
+				}
+				servePaths(w, r)
+			}), WithTokenInHeader())
+
+			if err := tt.call(c); err != nil {
+				t.Fatal(err)
+			}
+			if requests != 1 {
+				t.Errorf("requests = %d, want 1", requests)
+			}
+		})
+	}
+}
+
+func TestTokenInQueryByDefault(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("token"); got != "test-token" {
+			t.Errorf("token = %q, want test-token", got)
+		}
+		if got := r.Header.Get("X-Access-Token"); got != "" {
+			t.Errorf("X-Access-Token = %q, want none", got)
+		}
+	}))
+
+	if err := c.LikeMessage("g1", "m1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// rotatingSource hands out tokens in turn, repeating the last.
+type rotatingSource struct {
+	mu     sync.Mutex
+	tokens []string
+	calls  int
+}
+
+func (s *rotatingSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token := s.tokens[min(s.calls, len(s.tokens)-1)]
+	s.calls++
+	return token, nil
+}
+
+func TestTokenSourceRotation(t *testing.T) {
+	var sent []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		sent = append(sent, token)
+		if token != "new" {
+			writeMetaError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		writeEnvelope(w, http.StatusOK, nil)
+	}), WithTokenSource(&rotatingSource{tokens: []string{"old", "new"}}))
+
+	if err := c.LikeMessage("g1", "m1"); err != nil {
+		t.Fatalf("LikeMessage: %v", err)
+	}
+	if want := []string{"old", "new"}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("tokens sent = %v, want %v", sent, want)
+	}
+}
+
+func TestTokenSourceRejected(t *testing.T) {
+	var requests int
+	source := &rotatingSource{tokens: []string{"old", "still-bad"}}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeMetaError(w, http.StatusUnauthorized, "unauthorized")
+	}), WithTokenSource(source))
+
+	err := c.LikeMessage("g1", "m1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("err = %v, want a 401 *APIError", err)
+	}
+	// retried once with a fresh token, then given up on
+	if requests != 2 || source.calls != 2 {
+		t.Errorf("requests = %d, token calls = %d; want 2 and 2", requests, source.calls)
+	}
+}
+
+// pathCalls are calls made on each of a Client's request paths: to the API,
+// to the image service, and as a bot.
+var pathCalls = []struct {
+	name     string
+	endpoint string
+	call     func(c *Client) error
+}{
+	{"api", "messages.like", func(c *Client) error {
+		return c.LikeMessage("g1", "m1")
+	}},
+	{"image service", "images.upload", func(c *Client) error {
+		_, err := c.UploadImage(context.Background(), strings.NewReader("PNGDATA"), "image/png")
+		return err
+	}},
+	{"bot post", "bots.post", func(c *Client) error {
+		return c.PostBotMessage(context.Background(), "b1", "hello", nil)
+	}},
+}
+
+// servePaths answers the requests made by pathCalls.
+func servePaths(w http.ResponseWriter, r *http.Request) {
+	switch r.Host {
+	case "image.groupme.com":
+		io.WriteString(w, `{"payload":{"url":"https://i.groupme.com/1","picture_url":"https://i.groupme.com/1.png"}}`)
+	default:
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	for _, tt := range pathCalls {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+			record := func(name string) Middleware {
+				return func(next RequestFunc) RequestFunc {
+					return func(req *http.Request) (*http.Response, error) {
+						events = append(events, name+" request")
+						resp, err := next(req)
+						events = append(events, name+" response")
+						return resp, err
+					}
+				}
+			}
+
+			c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				events = append(events, "server")
+				servePaths(w, r)
+			}), WithMiddleware(record("first")))
+			c.Use(record("second"), record("third"))
+
+			if err := tt.call(c); err != nil {
+				t.Fatal(err)
+			}
+			want := []string{
+				"first request", "second request", "third request",
+				"server",
+				"third response", "second response", "first response",
+			}
+			if !reflect.DeepEqual(events, want) {
+				t.Errorf("events = %v, want %v", events, want)
+			}
+		})
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	blocked := errors.New("blocked")
+	c.Use(func(next RequestFunc) RequestFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, blocked
+		}
+	})
+
+	if err := c.LikeMessage("g1", "m1"); !errors.Is(err, blocked) {
+		t.Errorf("err = %v, want the middleware's error", err)
+	}
+	if requests != 0 {
+		t.Errorf("requests = %d, want 0", requests)
+	}
+}
+
+func TestClientHooks(t *testing.T) {
+	for _, tt := range pathCalls {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("User-Agent"); got != "dispatch/1.0" {
+					t.Errorf("User-Agent = %q, want dispatch/1.0", got)
+				}
+				if got := r.Header.Get("X-Station"); got != "12" {
+					t.Errorf("X-Station = %q, want 12", got)
+				}
+				servePaths(w, r)
+			}), WithUserAgent("dispatch/1.0"), WithHeader("X-Station", "12"), WithLogger(logger))
+
+			if err := tt.call(c); err != nil {
+				t.Fatal(err)
+			}
+
+			var entry struct {
+				Msg      string `json:"msg"`
+				Level    string `json:"level"`
+				Endpoint string `json:"endpoint"`
+				Method   string `json:"method"`
+				Status   int    `json:"status"`
+			}
+			if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+				t.Fatalf("log %q: %v", logs.String(), err)
+			}
+			if entry.Msg != "groupme request" || entry.Level != "DEBUG" || entry.Endpoint != tt.endpoint || entry.Method == "" || entry.Status == 0 {
+				t.Errorf("logged %+v, want a debug entry for %s", entry, tt.endpoint)
+			}
+			if strings.Contains(logs.String(), "test-token") {
+				t.Errorf("token logged: %s", logs.String())
+			}
+		})
+	}
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"default", nil, DefaultUserAgent},
+		{"from header", []Option{WithHeader("User-Agent", "custom/2")}, "custom/2"},
+		{"option wins", []Option{WithHeader("User-Agent", "custom/2"), WithUserAgent("dispatch/1.0")}, "dispatch/1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("User-Agent"); got != tt.want {
+					t.Errorf("User-Agent = %q, want %q", got, tt.want)
+				}
+			}), tt.opts...)
+
+			if err := c.LikeMessage("g1", "m1"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package groupme
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// CreateDirectMessagePayload is the body of a direct message create request
+// (`POST /direct_messages`).
+type CreateDirectMessagePayload struct {
+	DirectMessage struct {
+		SourceGUID  string       `json:"source_guid"`
+		RecipientID string       `json:"recipient_id"`
+		Text        string       `json:"text"`
+		Attachments []Attachment `json:"attachments,omitempty"`
+	} `json:"direct_message"`
+}
+
+// CreateDirectMessage validates and sends a direct message to a user. It
+// accepts the same attachments as CreateRichMessage, including ImageFile
+// uploads, except mentions, which have no meaning outside a group.
+func (c *Client) CreateDirectMessage(ctx context.Context, recipientID string, msg OutgoingMessage) (*Message, error) {
+	attachments, err := CombineAttachments(msg.Attachments...)
+	if err != nil {
+		return nil, err
+	}
+	msg.Attachments = attachments
+	if err := validateOutgoing(msg); err != nil {
+		return nil, err
+	}
+	for _, a := range msg.Attachments {
+		if a.Type == MentionsAttachment {
+			return nil, fmt.Errorf("%w: direct messages cannot carry mentions", ErrInvalidMessage)
+		}
+	}
+
+	payload := CreateDirectMessagePayload{}
+	payload.DirectMessage.SourceGUID = msg.SourceGUID
+	if payload.DirectMessage.SourceGUID == "" {
+		payload.DirectMessage.SourceGUID = NewSourceGUID()
+	}
+	payload.DirectMessage.RecipientID = recipientID
+	payload.DirectMessage.Text = msg.Text
+	payload.DirectMessage.Attachments, err = c.uploadImages(ctx, msg.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		DirectMessage *Message `json:"direct_message"`
+	}
+	err = c.doRequest(ctx, "direct_messages.create", http.MethodPost, "/direct_messages", nil, payload, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DirectMessage, nil
+}
+
+// ConversationID returns the conversation ID of the direct message
+// conversation between two users, which the like and delete endpoints expect
+// in place of a group ID: the two user IDs in ascending order, joined by "+".
+// A group's conversation ID is simply its group ID.
+func ConversationID(selfUserID, otherUserID string) string {
+	a, b := selfUserID, otherUserID
+	if userIDLess(b, a) {
+		a, b = b, a
+	}
+	return a + "+" + b
+}
+
+// userIDLess orders user IDs numerically, falling back to string order for
+// IDs that are not numbers.
+func userIDLess(a, b string) bool {
+	x, errA := strconv.ParseUint(a, 10, 64)
+	y, errB := strconv.ParseUint(b, 10, 64)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return x < y
+}
+
+// LikeDirectMessage likes a direct message between the authenticated user and
+// another user.
+func (c *Client) LikeDirectMessage(selfUserID, otherUserID, messageID string) error {
+	return c.LikeDirectMessageWithContext(context.Background(), selfUserID, otherUserID, messageID)
+}
+
+// LikeDirectMessageWithContext is LikeDirectMessage with a context.
+func (c *Client) LikeDirectMessageWithContext(ctx context.Context, selfUserID, otherUserID, messageID string) error {
+	return c.LikeMessageWithContext(ctx, ConversationID(selfUserID, otherUserID), messageID)
+}
+
+// UnlikeDirectMessage unlikes a direct message between the authenticated user
+// and another user.
+func (c *Client) UnlikeDirectMessage(selfUserID, otherUserID, messageID string) error {
+	return c.UnlikeDirectMessageWithContext(context.Background(), selfUserID, otherUserID, messageID)
+}
+
+// UnlikeDirectMessageWithContext is UnlikeDirectMessage with a context.
+func (c *Client) UnlikeDirectMessageWithContext(ctx context.Context, selfUserID, otherUserID, messageID string) error {
+	return c.UnlikeMessageWithContext(ctx, ConversationID(selfUserID, otherUserID), messageID)
+}
+
+// DeleteDirectMessage deletes a direct message between the authenticated user
+// and another user.
+func (c *Client) DeleteDirectMessage(selfUserID, otherUserID, messageID string) error {
+	return c.DeleteDirectMessageWithContext(context.Background(), selfUserID, otherUserID, messageID)
+}
+
+// DeleteDirectMessageWithContext is DeleteDirectMessage with a context.
+func (c *Client) DeleteDirectMessageWithContext(ctx context.Context, selfUserID, otherUserID, messageID string) error {
+	return c.DeleteMessageWithContext(ctx, ConversationID(selfUserID, otherUserID), messageID)
+}
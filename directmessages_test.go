@@ -0,0 +1,109 @@
+package groupme
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCreateDirectMessageAttachments(t *testing.T) {
+	var payload CreateDirectMessagePayload
+	var uploaded string
+	c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Host {
+		case "image.groupme.com":
+			if r.Header.Get("X-Access-Token") != "test-token" || r.Header.Get("Content-Type") != "image/png" {
+				t.Errorf("upload headers = %v", r.Header)
+			}
+			body, _ := io.ReadAll(r.Body)
+			uploaded = string(body)
+			io.WriteString(w, `{"payload":{"url":"https://i.groupme.com/1","picture_url":"https://i.groupme.com/1.png"}}`)
+		default:
+			if r.URL.Path != "/direct_messages" {
+				t.Errorf("path = %s", r.URL.Path)
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			writeEnvelope(w, http.StatusCreated, map[string]*Message{"direct_message": {ID: "dm1", RecipientID: "u2"}})
+		}
+	}))
+
+	msg, err := c.CreateDirectMessage(context.Background(), "u2", OutgoingMessage{
+		Text:       "pics",
+		SourceGUID: "guid-1",
+		Attachments: []Attachment{
+			ImageFile(strings.NewReader("PNGDATA"), "image/png"),
+			NewLocationAttachment("Station 1", "41.8", "-71.8"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateDirectMessage: %v", err)
+	}
+	if msg.ID != "dm1" || msg.RecipientID != "u2" {
+		t.Errorf("message = %+v", msg)
+	}
+	if uploaded != "PNGDATA" {
+		t.Errorf("uploaded %q", uploaded)
+	}
+
+	dm := payload.DirectMessage
+	want := []Attachment{
+		NewImageAttachment("https://i.groupme.com/1.png"),
+		NewLocationAttachment("Station 1", "41.8", "-71.8"),
+	}
+	if dm.RecipientID != "u2" || dm.SourceGUID != "guid-1" || dm.Text != "pics" || !reflect.DeepEqual(dm.Attachments, want) {
+		t.Errorf("payload = %+v", dm)
+	}
+}
+
+func TestCreateDirectMessageRejectsMentions(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+
+	mention := Attachment{Type: MentionsAttachment, UserIDs: []string{"u3"}, Loci: [][]int{{0, 2}}}
+	_, err := c.CreateDirectMessage(context.Background(), "u2", OutgoingMessage{Text: "@x", Attachments: []Attachment{mention}})
+	if !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("err = %v, want ErrInvalidMessage", err)
+	}
+}
+
+func TestConversationID(t *testing.T) {
+	tests := []struct {
+		self, other string
+		want        string
+	}{
+		{"111", "222", "111+222"},
+		{"222", "111", "111+222"},
+		// numeric, not string, order
+		{"9", "10", "9+10"},
+		{"10", "9", "9+10"},
+		{"29449201", "3069232", "3069232+29449201"},
+		{"abc", "abd", "abc+abd"},
+		{"5", "5", "5+5"},
+	}
+
+	for _, tt := range tests {
+		if got := ConversationID(tt.self, tt.other); got != tt.want {
+			t.Errorf("ConversationID(%q, %q) = %q, want %q", tt.self, tt.other, got, tt.want)
+		}
+	}
+}
+
+func TestLikeDirectMessage(t *testing.T) {
+	var got string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Path
+	}))
+
+	if err := c.LikeDirectMessage("20", "3", "m1"); err != nil {
+		t.Fatalf("LikeDirectMessage: %v", err)
+	}
+	if want := "/messages/3+20/m1/like"; got != want {
+		t.Errorf("path = %s, want %s", got, want)
+	}
+}
@@ -2,7 +2,11 @@ package groupme
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
+	"time"
 )
 
 // StatusEnhanceYourCalm is "returned when you are being rate limited. Chill the heck out."
@@ -16,11 +20,55 @@ var (
 	ErrForbidden           = errors.New("403 Forbidden")
 	ErrNotFound            = errors.New("404 Not Found")
 	ErrEnhanceYourCalm     = errors.New("420 Enhance Your Calm")
+	ErrTooManyRequests     = errors.New("429 Too Many Requests")
 	ErrInternalServerError = errors.New("500 Internal Server Error")
 	ErrBadGateway          = errors.New("502 Bad Gateway")
 	ErrServiceUnavailable  = errors.New("503 Service Unavailable")
 )
 
+// Errors returned by this package.
+var (
+	// ErrInvalidVersion is returned when a Client's API version is malformed.
+	ErrInvalidVersion = errors.New("groupme: invalid API version")
+
+	// ErrNotMember is returned when the user is not (or is no longer) a
+	// member of a group. From GetMessages it wraps the APIError GroupMe
+	// responded with.
+	ErrNotMember = errors.New("groupme: not a member of the group")
+
+	// ErrNoMessages is returned when a group has no messages.
+	ErrNoMessages = errors.New("groupme: group has no messages")
+
+	// ErrBotNotFound is returned when no bot matches a lookup.
+	ErrBotNotFound = errors.New("groupme: bot not found")
+
+	// ErrNotOnLeaderboard is returned when a message is not on a leaderboard.
+	ErrNotOnLeaderboard = errors.New("groupme: message is not on the leaderboard")
+
+	// ErrInvalidMessage is returned when an outgoing message would be
+	// rejected by GroupMe.
+	ErrInvalidMessage = errors.New("groupme: invalid message")
+
+	// ErrNoAvatar is returned when a group has no avatar image.
+	ErrNoAvatar = errors.New("groupme: group has no avatar")
+
+	// ErrResultsPending is returned when the results of adding members are
+	// not ready yet.
+	ErrResultsPending = errors.New("groupme: add member results are not ready")
+
+	// ErrReplyNotFound is returned when the message a reply quotes has been
+	// deleted or cannot be read.
+	ErrReplyNotFound = errors.New("groupme: reply target not found")
+
+	// ErrNotGroupMeMedia is returned by DownloadAttachment for URLs that are
+	// not hosted by GroupMe.
+	ErrNotGroupMeMedia = errors.New("groupme: not a GroupMe media URL")
+
+	// ErrDownloadTooLarge is returned by DownloadAttachment for files larger
+	// than MaxDownloadSize.
+	ErrDownloadTooLarge = errors.New("groupme: download too large")
+)
+
 // Meta is the error response from the GroupMe API.
 type Meta struct {
 	Code   int      `json:"code"`
@@ -29,8 +77,6 @@ type Meta struct {
 
 func parseError(statusCode int, status string) error {
 	switch statusCode {
-	case http.StatusNotModified:
-		return ErrNotModified
 	case http.StatusBadRequest:
 		return ErrBadRequest
 	case http.StatusUnauthorized:
@@ -41,6 +87,8 @@ func parseError(statusCode int, status string) error {
 		return ErrNotFound
 	case StatusEnhanceYourCalm:
 		return ErrEnhanceYourCalm
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
 	case http.StatusInternalServerError:
 		return ErrInternalServerError
 	case http.StatusBadGateway:
@@ -51,3 +99,96 @@ func parseError(statusCode int, status string) error {
 		return errors.New(status)
 	}
 }
+
+// APIError is an error response from the GroupMe API. It wraps the matching
+// sentinel error (ErrNotFound, ErrBadRequest, ...), so it can be tested with
+// errors.Is as well as inspected with errors.As. Every request this package
+// makes, including Bot.Post and the image and powerup services, reports error
+// responses as an APIError.
+type APIError struct {
+	StatusCode int
+	MetaCode   int
+	Errors     []string
+
+	// Errors that name the field they concern, e.g. "text is too long".
+	FieldErrors []FieldError
+
+	// Errors that don't name a field.
+	Messages []string
+
+	// RetryAfter is how long GroupMe asked to wait before retrying a rate
+	// limited request, or 0 if it did not say.
+	RetryAfter time.Duration
+
+	// set on 5xx responses without GroupMe's JSON, such as the HTML pages
+	// served during outages and maintenance
+	outage bool
+}
+
+// FieldError is a validation error about one field of a request.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func newAPIError(statusCode int, meta Meta) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		MetaCode:   meta.Code,
+		Errors:     meta.Errors,
+	}
+	for _, msg := range meta.Errors {
+		if m := fieldErrorRegexp.FindStringSubmatch(msg); m != nil {
+			e.FieldErrors = append(e.FieldErrors, FieldError{Field: strings.ToLower(m[1]), Message: m[2]})
+			continue
+		}
+		e.Messages = append(e.Messages, msg)
+	}
+	return e
+}
+
+// fieldErrorRegexp matches validation errors of the form "<field> <problem>".
+var fieldErrorRegexp = regexp.MustCompile(`^([A-Za-z_]+) ((?:is|are|can't|cannot|must|has|have|should|was|does|doesn't) .*)$`)
+
+// code returns the code that reported the error.
+func (e *APIError) code() int {
+	if e.MetaCode != 0 && !isSuccess(e.MetaCode) {
+		return e.MetaCode
+	}
+	return e.StatusCode
+}
+
+func (e *APIError) Error() string {
+	status := fmt.Sprintf("%d %s", e.code(), http.StatusText(e.code()))
+	if len(e.Errors) == 0 {
+		return status
+	}
+	return fmt.Sprintf("%s: %+v", status, e.Errors)
+}
+
+// Is reports whether an outage response matches ErrServiceUnavailable, as it
+// does whatever its 5xx status code.
+func (e *APIError) Is(target error) bool {
+	return e.outage && target == ErrServiceUnavailable
+}
+
+// Unwrap returns the sentinel error for the error's code.
+func (e *APIError) Unwrap() error {
+	return parseError(e.code(), fmt.Sprintf("%d %s", e.code(), http.StatusText(e.code())))
+}
+
+// checkResponse returns the error indicated by a response's HTTP status code
+// or its meta code. GroupMe may report an error through either one, so a
+// failure in either is an error; the meta code wins when both fail since it
+// comes with GroupMe's error messages.
+func checkResponse(statusCode int, meta Meta) error {
+	if (meta.Code != 0 && !isSuccess(meta.Code)) || !isSuccess(statusCode) {
+		return newAPIError(statusCode, meta)
+	}
+
+	return nil
+}
+
+func isSuccess(code int) bool {
+	return code >= 200 && code <= 299
+}
@@ -0,0 +1,130 @@
+package groupme
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestStatusAndMetaCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		metaCode int
+		want     error
+	}{
+		{"ok", http.StatusOK, http.StatusOK, nil},
+		{"error meta on 200", http.StatusOK, http.StatusBadRequest, ErrBadRequest},
+		{"error status with ok meta", http.StatusNotFound, http.StatusOK, ErrNotFound},
+		{"both fail, meta wins", http.StatusBadRequest, http.StatusUnauthorized, ErrUnauthorized},
+		{"error status without meta", http.StatusBadGateway, 0, ErrBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"response": map[string]interface{}{"messages": []*Message{}},
+					"meta":     Meta{Code: tt.metaCode},
+				})
+			}))
+
+			check := func(method string, err error) {
+				if tt.want == nil {
+					if err != nil {
+						t.Errorf("%s: err = %v, want nil", method, err)
+					}
+					return
+				}
+				if !errors.Is(err, tt.want) {
+					t.Errorf("%s: err = %v, want %v", method, err, tt.want)
+				}
+			}
+
+			_, err := c.GetMessages("g1", "", "", "", "")
+			check("GetMessages", err)
+			_, err = c.CreateMessage("g1", "guid", "hi")
+			check("CreateMessage", err)
+		})
+	}
+}
+
+func TestAPIErrorFieldErrors(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMetaError(w, http.StatusBadRequest, "Text is too long", "source_guid has already been taken", "Something went wrong")
+	}))
+
+	_, err := c.CreateMessage("g1", "guid", "hi")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an APIError", err)
+	}
+
+	wantFields := []FieldError{
+		{Field: "text", Message: "is too long"},
+		{Field: "source_guid", Message: "has already been taken"},
+	}
+	if !reflect.DeepEqual(apiErr.FieldErrors, wantFields) {
+		t.Errorf("FieldErrors = %+v, want %+v", apiErr.FieldErrors, wantFields)
+	}
+	if want := []string{"Something went wrong"}; !reflect.DeepEqual(apiErr.Messages, want) {
+		t.Errorf("Messages = %q, want %q", apiErr.Messages, want)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.MetaCode != http.StatusBadRequest {
+		t.Errorf("StatusCode = %d, MetaCode = %d", apiErr.StatusCode, apiErr.MetaCode)
+	}
+	if want := "400 Bad Request: [Text is too long source_guid has already been taken Something went wrong]"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestOutagePage(t *testing.T) {
+	tests := []struct {
+		status  int
+		message string
+		also    error
+	}{
+		{http.StatusServiceUnavailable, "503 Service Unavailable", ErrServiceUnavailable},
+		{http.StatusBadGateway, "502 Bad Gateway", ErrBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(tt.status)
+				io.WriteString(w, "<html><body><h1>GroupMe is down for maintenance</h1></body></html>")
+			}))
+
+			_, err := c.GetMessages("g1", "", "", "", "")
+			if err == nil {
+				t.Fatal("err = nil")
+			}
+			if err.Error() != tt.message {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.message)
+			}
+			if !errors.Is(err, ErrServiceUnavailable) || !errors.Is(err, tt.also) {
+				t.Errorf("err = %v, want ErrServiceUnavailable and %v", err, tt.also)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("err = %#v, want an APIError with status %d", err, tt.status)
+			}
+		})
+	}
+}
+
+func TestServerErrorWithMeta(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMetaError(w, http.StatusInternalServerError, "boom")
+	}))
+
+	_, err := c.GetMessages("g1", "", "", "", "")
+	if !errors.Is(err, ErrInternalServerError) || errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("err = %v, want ErrInternalServerError only", err)
+	}
+}
@@ -1,10 +1,22 @@
 package groupme
 
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
 // Event types.
 const (
 	MemberAddedEventType     = "membership.announce.added"
 	MemberRemovedEventType   = "membership.notifications.removed"
+	MemberExitedEventType    = "membership.notifications.exited"
 	NicknameChangedEventType = "membership.nickname_changed"
+	MessageDeletedEventType  = "message.deleted"
+
+	CalendarEventCreatedEventType   = "calendar.event.created"
+	CalendarEventUpdatedEventType   = "calendar.event.updated"
+	CalendarEventCancelledEventType = "calendar.event.cancelled"
 )
 
 // EventData keys.
@@ -89,3 +101,115 @@ func ParseUsersEventData(i interface{}) (UsersEventData, bool) {
 
 	return ds, true
 }
+
+// MemberAddedEvent is the data of a member added event.
+type MemberAddedEvent struct {
+	AdderUserID string
+	AdderUser   UserRef
+	AddedUsers  []UserRef
+}
+
+// MemberRemovedEvent is the data of a member removed or exited event.
+// RemoverUserID is empty if the member left on their own.
+type MemberRemovedEvent struct {
+	RemoverUserID string
+	RemoverUser   UserRef
+	RemovedUser   UserRef
+	Left          bool
+}
+
+// userRef converts a UserEventData into a UserRef.
+func (d UserEventData) userRef() UserRef {
+	return UserRef{ID: strconv.Itoa(d.ID), Name: d.Nickname}
+}
+
+// ParseMemberAddedEvent parses the data of a member added event if possible.
+func (e *Event) ParseMemberAddedEvent() (MemberAddedEvent, bool) {
+	d := MemberAddedEvent{}
+	if e.Type != MemberAddedEventType {
+		return d, false
+	}
+
+	adder, ok := ParseUserEventData(e.Data[AdderUserKey])
+	if !ok {
+		return d, ok
+	}
+	d.AdderUser = adder.userRef()
+	d.AdderUserID = d.AdderUser.ID
+
+	added, ok := ParseUsersEventData(e.Data[AddedUsersKey])
+	if !ok {
+		return d, ok
+	}
+	for _, user := range added {
+		d.AddedUsers = append(d.AddedUsers, user.userRef())
+	}
+
+	return d, true
+}
+
+// ParseMemberRemovedEvent parses the data of a member removed or exited event
+// if possible.
+func (e *Event) ParseMemberRemovedEvent() (MemberRemovedEvent, bool) {
+	d := MemberRemovedEvent{}
+	switch e.Type {
+	case MemberRemovedEventType:
+		remover, ok := ParseUserEventData(e.Data[RemoverUserKey])
+		if !ok {
+			return d, ok
+		}
+		d.RemoverUser = remover.userRef()
+		d.RemoverUserID = d.RemoverUser.ID
+	case MemberExitedEventType:
+		d.Left = true
+	default:
+		return d, false
+	}
+
+	removed, ok := ParseUserEventData(e.Data[RemovedUserKey])
+	if !ok {
+		return d, ok
+	}
+	d.RemovedUser = removed.userRef()
+
+	return d, true
+}
+
+// CalendarEventData is the data of a calendar event system message.
+type CalendarEventData struct {
+	User  UserEventData `json:"user"`
+	Event struct {
+		ID      string    `json:"id"`
+		Name    string    `json:"name"`
+		StartAt time.Time `json:"start_at"`
+		EndAt   time.Time `json:"end_at"`
+	} `json:"event"`
+}
+
+// IsCalendarEvent returns whether the event is a calendar event being created,
+// updated or cancelled.
+func (e *Event) IsCalendarEvent() bool {
+	switch e.Type {
+	case CalendarEventCreatedEventType, CalendarEventUpdatedEventType, CalendarEventCancelledEventType:
+		return true
+	}
+	return false
+}
+
+// ParseCalendarEventData parses the data of a calendar event if possible.
+func (e *Event) ParseCalendarEventData() (CalendarEventData, bool) {
+	d := CalendarEventData{}
+	if !e.IsCalendarEvent() {
+		return d, false
+	}
+
+	buf, err := json.Marshal(e.Data)
+	if err != nil {
+		return d, false
+	}
+	if err := json.Unmarshal(buf, &d); err != nil {
+		return d, false
+	}
+
+	return d, d.Event.ID != ""
+}
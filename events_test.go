@@ -0,0 +1,180 @@
+package groupme
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEventRoundTrip(t *testing.T) {
+	const raw = `{"type":"membership.announce.added","data":{"added_users":[{"id":2222,"nickname":"Jamie"}],"adder_user":{"id":1111,"nickname":"Alex"}}}`
+
+	var e Event
+	if err := json.Unmarshal([]byte(raw), &e); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if e.Type != MemberAddedEventType || !e.Exists() {
+		t.Fatalf("Event = %+v", e)
+	}
+
+	buf, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(buf) != raw {
+		t.Errorf("Marshal =\n%s\nwant\n%s", buf, raw)
+	}
+}
+
+func TestParseUsersEventData(t *testing.T) {
+	var e Event
+	if err := json.Unmarshal([]byte(`{"type":"x","data":{"users":[{"id":1,"nickname":"A"},{"id":2,"nickname":"B"}],"bad":[{"id":"1"}]}}`), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	users, ok := ParseUsersEventData(e.Data["users"])
+	if want := (UsersEventData{{ID: 1, Nickname: "A"}, {ID: 2, Nickname: "B"}}); !ok || !reflect.DeepEqual(users, want) {
+		t.Errorf("ParseUsersEventData = %+v, %t, want %+v", users, ok, want)
+	}
+	if _, ok := ParseUsersEventData(e.Data["bad"]); ok {
+		t.Error("ParseUsersEventData with a string id: ok = true")
+	}
+	if _, ok := ParseUserEventData(nil); ok {
+		t.Error("ParseUserEventData(nil): ok = true")
+	}
+}
+
+func TestParseCalendarEventData(t *testing.T) {
+	const raw = `{"type":"calendar.event.created","data":{"user":{"id":1111,"nickname":"Alex"},"event":{"id":"e1","name":"Drill night","start_at":"2023-11-14T23:00:00Z","end_at":"2023-11-15T01:00:00Z"}}}`
+
+	var e Event
+	if err := json.Unmarshal([]byte(raw), &e); err != nil {
+		t.Fatal(err)
+	}
+	if !e.IsCalendarEvent() {
+		t.Fatal("IsCalendarEvent() = false")
+	}
+
+	d, ok := e.ParseCalendarEventData()
+	if !ok {
+		t.Fatal("ParseCalendarEventData: ok = false")
+	}
+	if d.User != (UserEventData{ID: 1111, Nickname: "Alex"}) || d.Event.ID != "e1" || d.Event.Name != "Drill night" {
+		t.Errorf("data = %+v", d)
+	}
+	if want := time.Date(2023, 11, 14, 23, 0, 0, 0, time.UTC); !d.Event.StartAt.Equal(want) {
+		t.Errorf("StartAt = %s, want %s", d.Event.StartAt, want)
+	}
+	if want := time.Date(2023, 11, 15, 1, 0, 0, 0, time.UTC); !d.Event.EndAt.Equal(want) {
+		t.Errorf("EndAt = %s, want %s", d.Event.EndAt, want)
+	}
+}
+
+func TestParseCalendarEventDataRejects(t *testing.T) {
+	tests := []string{
+		`{"type":"membership.announce.added","data":{"event":{"id":"e1"}}}`,
+		`{"type":"calendar.event.updated","data":{"event":{"name":"no id"}}}`,
+		`{"type":"calendar.event.cancelled","data":{"event":{"id":"e1","start_at":"tomorrow"}}}`,
+	}
+
+	for _, raw := range tests {
+		var e Event
+		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := e.ParseCalendarEventData(); ok {
+			t.Errorf("ParseCalendarEventData(%s): ok = true", raw)
+		}
+	}
+}
+
+func TestParseMemberAddedEvent(t *testing.T) {
+	const raw = `{"type":"membership.announce.added","data":{"added_users":[{"id":2222,"nickname":"Jamie"},{"id":3333,"nickname":"Sam"}],"adder_user":{"id":1111,"nickname":"Alex"}}}`
+
+	var e Event
+	if err := json.Unmarshal([]byte(raw), &e); err != nil {
+		t.Fatal(err)
+	}
+	d, ok := e.ParseMemberAddedEvent()
+	if !ok {
+		t.Fatal("ParseMemberAddedEvent: ok = false")
+	}
+
+	want := MemberAddedEvent{
+		AdderUserID: "1111",
+		AdderUser:   UserRef{ID: "1111", Name: "Alex"},
+		AddedUsers:  []UserRef{{ID: "2222", Name: "Jamie"}, {ID: "3333", Name: "Sam"}},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("ParseMemberAddedEvent = %+v, want %+v", d, want)
+	}
+
+	if _, ok := e.ParseMemberRemovedEvent(); ok {
+		t.Error("ParseMemberRemovedEvent of an added event: ok = true")
+	}
+}
+
+func TestParseMemberRemovedEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want MemberRemovedEvent
+	}{
+		{
+			name: "removed",
+			json: `{"type":"membership.notifications.removed","data":{"remover_user":{"id":1111,"nickname":"Alex"},"removed_user":{"id":2222,"nickname":"Jamie"}}}`,
+			want: MemberRemovedEvent{
+				RemoverUserID: "1111",
+				RemoverUser:   UserRef{ID: "1111", Name: "Alex"},
+				RemovedUser:   UserRef{ID: "2222", Name: "Jamie"},
+			},
+		},
+		{
+			name: "exited",
+			json: `{"type":"membership.notifications.exited","data":{"removed_user":{"id":2222,"nickname":"Jamie"}}}`,
+			want: MemberRemovedEvent{
+				RemovedUser: UserRef{ID: "2222", Name: "Jamie"},
+				Left:        true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e Event
+			if err := json.Unmarshal([]byte(tt.json), &e); err != nil {
+				t.Fatal(err)
+			}
+			d, ok := e.ParseMemberRemovedEvent()
+			if !ok {
+				t.Fatal("ParseMemberRemovedEvent: ok = false")
+			}
+			if d != tt.want {
+				t.Errorf("ParseMemberRemovedEvent = %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMemberEventsMalformed(t *testing.T) {
+	tests := []string{
+		`{"type":"membership.announce.added","data":{"adder_user":{"id":"1111"},"added_users":[]}}`,
+		`{"type":"membership.announce.added","data":{"adder_user":{"id":1111,"nickname":"Alex"},"added_users":{"id":2}}}`,
+		`{"type":"membership.notifications.removed","data":{"removed_user":{"id":2222,"nickname":"Jamie"}}}`,
+		`{"type":"membership.notifications.exited","data":{}}`,
+	}
+
+	for _, raw := range tests {
+		var e Event
+		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := e.ParseMemberAddedEvent(); ok {
+			t.Errorf("ParseMemberAddedEvent(%s): ok = true", raw)
+		}
+		if _, ok := e.ParseMemberRemovedEvent(); ok {
+			t.Errorf("ParseMemberRemovedEvent(%s): ok = true", raw)
+		}
+	}
+}
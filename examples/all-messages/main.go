@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/MagnusFrater/groupme"
+	"github.com/dayvillefire/groupme"
 )
 
 func main() {
@@ -19,7 +19,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	client := groupme.NewClient(groupme.V3BaseURL, *accessToken)
+	client, err := groupme.NewClient(*accessToken)
+	if err != nil {
+		panic(err)
+	}
 	bot := groupme.NewBot(groupme.V3BaseURL, *botID, *groupID, "", "")
 
 	messages, err := client.AllMessages(bot.GroupID)
@@ -6,7 +6,7 @@ import (
 	"os"
 	"strconv"
 
-	"github.com/MagnusFrater/groupme"
+	"github.com/dayvillefire/groupme"
 )
 
 func main() {
@@ -21,7 +21,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	client := groupme.NewClient(groupme.V3BaseURL, *accessToken)
+	client, err := groupme.NewClient(*accessToken)
+	if err != nil {
+		panic(err)
+	}
 	bot := groupme.NewBot(groupme.V3BaseURL, *botID, *groupID, "", "")
 
 	messages, err := client.GetMessages(bot.GroupID, strconv.Itoa(*limit), "", "", "")
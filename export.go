@@ -0,0 +1,130 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+// ExportTimeFormat is the layout timestamps are rendered with in exports.
+const ExportTimeFormat = "2006-01-02 15:04:05 MST"
+
+// ExportOptions configures ExportMessages and ExportTranscriptHTML.
+type ExportOptions struct {
+	// Location is the time zone timestamps are rendered in. Defaults to
+	// time.Local.
+	Location *time.Location
+}
+
+func (o ExportOptions) formatTime(m *Message) string {
+	loc := o.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	return m.CreatedAtTime().In(loc).Format(ExportTimeFormat)
+}
+
+// ExportMessages writes messages to w as a plain-text transcript, one line per
+// message, in the order given.
+func ExportMessages(w io.Writer, messages []*Message, opts ExportOptions) error {
+	for _, message := range messages {
+		_, err := fmt.Fprintf(w, "[%s] %s: %s\n", opts.formatTime(message), message.Name, message.Text)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExportNewMessages appends the messages in a group newer than the cursor in
+// store to w, in chronological order and in the format of ExportMessages,
+// saving the cursor as it goes. A FileCursorStore next to the export file
+// makes a suitable sidecar. With no saved cursor the whole history is
+// exported. Messages are written a page at a time as they arrive; if w has a
+// Flush method, as a *bufio.Writer does, it is flushed after each page, and
+// only then is the cursor advanced past the page, so a failed export resumes
+// after the last page written. It returns the number of messages written.
+func (c *Client) ExportNewMessages(ctx context.Context, w io.Writer, store CursorStore, groupID string, opts ExportOptions) (int, error) {
+	sinceID, err := store.Load(groupID)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	write := func(page []*Message) error {
+		if err := ExportMessages(w, page, opts); err != nil {
+			return err
+		}
+		if f, ok := w.(interface{ Flush() error }); ok {
+			if err := f.Flush(); err != nil {
+				return err
+			}
+		}
+		n += len(page)
+		return store.Save(groupID, page[len(page)-1].ID)
+	}
+
+	// GroupMe only pages forward after a message, so start from the oldest
+	if sinceID == "" {
+		first, err := c.FirstMessage(ctx, groupID)
+		if err != nil {
+			if errors.Is(err, ErrNoMessages) {
+				return 0, nil
+			}
+			return 0, err
+		}
+		if err := write([]*Message{first}); err != nil {
+			return n, err
+		}
+		sinceID = first.ID
+	}
+
+	err = c.pagesAfter(ctx, groupID, sinceID, write)
+	return n, err
+}
+
+var transcriptTemplate = template.Must(template.New("transcript").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>GroupMe transcript</title></head>
+<body>
+<ul>
+{{- range .}}
+<li><time>{{.Time}}</time> <strong>{{.Name}}</strong>: {{.Text}}
+{{- range .Images}} <img src="{{.}}">{{end}}</li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+// ExportTranscriptHTML writes messages to w as an HTML transcript, in the
+// order given.
+func ExportTranscriptHTML(w io.Writer, messages []*Message, opts ExportOptions) error {
+	type entry struct {
+		Time   string
+		Name   string
+		Text   string
+		Images []string
+	}
+
+	entries := make([]entry, 0, len(messages))
+	for _, message := range messages {
+		e := entry{
+			Time: opts.formatTime(message),
+			Name: message.Name,
+			Text: message.Text,
+		}
+		for _, a := range message.Attachments {
+			if a.Type == ImageAttachment {
+				e.Images = append(e.Images, a.URL)
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	return transcriptTemplate.Execute(w, entries)
+}
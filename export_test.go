@@ -0,0 +1,111 @@
+package groupme
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flushCheckingStore is a CursorStore that records, at each Save, how many
+// lines have reached the underlying writer.
+type flushCheckingStore struct {
+	*MemoryCursorStore
+	out   *bytes.Buffer
+	saves []string
+}
+
+func (s *flushCheckingStore) Save(groupID, id string) error {
+	s.saves = append(s.saves, fmt.Sprintf("%s@%d", id, strings.Count(s.out.String(), "\n")))
+	return s.MemoryCursorStore.Save(groupID, id)
+}
+
+func TestExportNewMessages(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+
+	var out bytes.Buffer
+	store := &flushCheckingStore{MemoryCursorStore: NewMemoryCursorStore(), out: &out}
+	store.MemoryCursorStore.Save("g1", "20")
+
+	w := bufio.NewWriterSize(&out, 1<<20)
+	n, err := c.ExportNewMessages(context.Background(), w, store, "g1", ExportOptions{Location: time.UTC})
+	if err != nil {
+		t.Fatalf("ExportNewMessages: %v", err)
+	}
+	if n != 230 {
+		t.Errorf("wrote %d messages, want 230", n)
+	}
+
+	// each page is flushed before the cursor moves past it
+	if want := []string{"120@100", "220@200", "250@230"}; !reflect.DeepEqual(store.saves, want) {
+		t.Errorf("saves = %v, want %v", store.saves, want)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 230 || !strings.HasSuffix(lines[0], ": message 21") || !strings.HasSuffix(lines[229], ": message 250") {
+		t.Errorf("export has %d lines, from %q to %q", len(lines), lines[0], lines[len(lines)-1])
+	}
+}
+
+func TestExportNewMessagesFailedPage(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	h.failOn = 3
+	c := newTestClient(t, h)
+	store := NewMemoryCursorStore()
+	store.Save("g1", "20")
+
+	var out bytes.Buffer
+	n, err := c.ExportNewMessages(context.Background(), &out, store, "g1", ExportOptions{})
+	if !errors.Is(err, ErrInternalServerError) {
+		t.Fatalf("err = %v, want ErrInternalServerError", err)
+	}
+	// the pages written so far stay written, and the next export resumes
+	// after them
+	if n != 200 || strings.Count(out.String(), "\n") != 200 {
+		t.Errorf("wrote %d messages, %d lines; want 200", n, strings.Count(out.String(), "\n"))
+	}
+	if cursor, _ := store.Load("g1"); cursor != "220" {
+		t.Errorf("cursor = %q, want 220", cursor)
+	}
+
+	h.failOn = 0
+	n, err = c.ExportNewMessages(context.Background(), &out, store, "g1", ExportOptions{})
+	if err != nil || n != 30 || strings.Count(out.String(), "\n") != 230 {
+		t.Errorf("resumed export = %d, %v; %d lines in all, want 30 more and 230", n, err, strings.Count(out.String(), "\n"))
+	}
+}
+
+func TestExportNewMessagesFromScratch(t *testing.T) {
+	h := newFakeHistory(150, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+	store := NewMemoryCursorStore()
+
+	var out bytes.Buffer
+	n, err := c.ExportNewMessages(context.Background(), &out, store, "g1", ExportOptions{})
+	if err != nil || n != 150 {
+		t.Fatalf("ExportNewMessages = %d, %v; want 150", n, err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if !strings.HasSuffix(lines[0], ": message 1") || !strings.HasSuffix(lines[149], ": message 150") {
+		t.Errorf("export runs from %q to %q", lines[0], lines[len(lines)-1])
+	}
+	if cursor, _ := store.Load("g1"); cursor != "150" {
+		t.Errorf("cursor = %q, want 150", cursor)
+	}
+}
+
+func TestExportNewMessagesEmptyGroup(t *testing.T) {
+	c := newTestClient(t, newFakeHistory(0, time.Unix(1700000000, 0)))
+	store := NewMemoryCursorStore()
+
+	var out bytes.Buffer
+	if n, err := c.ExportNewMessages(context.Background(), &out, store, "g1", ExportOptions{}); err != nil || n != 0 || out.Len() != 0 {
+		t.Errorf("ExportNewMessages = %d, %v, %q; want nothing", n, err, out.String())
+	}
+}
@@ -0,0 +1,113 @@
+package groupme
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fixtureMessages returns the JSON of each message in testdata/messages.json.
+func fixtureMessages(f *testing.F) [][]byte {
+	data, err := os.ReadFile(filepath.Join("testdata", "messages.json"))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	var envelope struct {
+		Response struct {
+			Messages []json.RawMessage `json:"messages"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		f.Fatal(err)
+	}
+
+	var messages [][]byte
+	for _, m := range envelope.Response.Messages {
+		messages = append(messages, m)
+	}
+	return messages
+}
+
+func FuzzMessageDecode(f *testing.F) {
+	for _, m := range fixtureMessages(f) {
+		f.Add(m)
+	}
+	f.Add([]byte(callbackBody))
+	f.Add([]byte(`{"event":{"type":"membership.notifications.removed","data":{"remover_user":{"id":1,"nickname":"A"},"removed_user":{"id":"2"}}}}`))
+	f.Add([]byte(`{"event":{"type":"membership.notifications.exited","data":{"removed_user":[]}}}`))
+	f.Add([]byte(`{"event":{"type":"calendar.event.created","data":{"user":{"id":1},"event":{"id":"e","start_at":"not a time"}}}}`))
+	f.Add([]byte(`{"event":{"type":"membership.announce.added","data":{"added_users":[null],"adder_user":7}}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var m Message
+		if err := json.Unmarshal(data, &m); err != nil {
+			return
+		}
+
+		// everything reachable from a decoded message must not panic
+		m.Event.Exists()
+		m.Event.ParseMemberAddedEvent()
+		m.Event.ParseMemberRemovedEvent()
+		m.Event.ParseCalendarEventData()
+		for _, v := range m.Event.Data {
+			ParseUserEventData(v)
+			ParseUsersEventData(v)
+		}
+		m.IsDeleted()
+		m.FirstImageURL()
+		m.MentionsEveryone()
+		m.ReactionCount("❤️")
+		for i := range m.Attachments {
+			m.Attachments[i].AsEventShare()
+			m.Attachments[i].AsLinkedImage()
+			m.Attachments[i].AsVideo()
+			m.Attachments[i].AsPoll()
+		}
+		if _, err := json.Marshal(m); err != nil {
+			t.Fatalf("Marshal of decoded message: %v", err)
+		}
+
+		if _, err := ParseCallback(bytes.NewReader(data)); err != nil {
+			t.Fatalf("ParseCallback rejected input json.Unmarshal accepted: %v", err)
+		}
+	})
+}
+
+func FuzzAttachmentDecode(f *testing.F) {
+	for _, m := range fixtureMessages(f) {
+		var message struct {
+			Attachments []json.RawMessage `json:"attachments"`
+		}
+		json.Unmarshal(m, &message)
+		for _, a := range message.Attachments {
+			f.Add([]byte(a))
+		}
+	}
+	f.Add([]byte(`{"type":"location","lat":1e400,"lng":"x"}`))
+	f.Add([]byte(`{"type":"location","lat":[1],"lng":{}}`))
+	f.Add([]byte(`{"type":"sticker","pack_id":5,"nested":{"a":[1,2,3]}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var a Attachment
+		if err := json.Unmarshal(data, &a); err != nil {
+			return
+		}
+
+		a.AsEventShare()
+		a.AsLinkedImage()
+		a.AsVideo()
+		a.AsPoll()
+
+		buf, err := json.Marshal(a)
+		if err != nil {
+			t.Fatalf("Marshal of decoded attachment: %v", err)
+		}
+		var again Attachment
+		if err := json.Unmarshal(buf, &again); err != nil {
+			t.Fatalf("Unmarshal of %s, marshaled from %s: %v", buf, data, err)
+		}
+	})
+}
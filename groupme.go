@@ -2,3 +2,12 @@ package groupme
 
 // V3BaseURL is GroupMe's v3 API base URL.
 const V3BaseURL = "https://api.groupme.com/v3"
+
+// DefaultBaseURL is GroupMe's API base URL, without a version.
+const DefaultBaseURL = "https://api.groupme.com"
+
+// DefaultVersion is the API version used when none is configured.
+const DefaultVersion = "v3"
+
+// DefaultUserAgent is the User-Agent sent by clients that do not set one.
+const DefaultUserAgent = "dayvillefire-groupme"
@@ -0,0 +1,391 @@
+package groupme
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Group is a GroupMe group.
+type Group struct {
+	ID          string `json:"id"`
+	GroupID     string `json:"group_id"`
+	Name        string `json:"name"`
+	PhoneNumber string `json:"phone_number"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url"`
+
+	CreatorUserID string `json:"creator_user_id"`
+	CreatedAt     int    `json:"created_at"`
+	UpdatedAt     int    `json:"updated_at"`
+
+	OfficeMode     bool   `json:"office_mode"`
+	ShareURL       string `json:"share_url"`
+	ShareQRCodeURL string `json:"share_qr_code_url"`
+	MaxMembers     int    `json:"max_members"`
+
+	// topic tags of public groups, as returned by the directory
+	Tags []string `json:"tags"`
+
+	// read state of the authenticated user, where GroupMe reports it
+	LastReadMessageID string `json:"last_read_message_id"`
+	LastReadAt        int    `json:"last_read_at"`
+
+	Members      []*Member     `json:"members"`
+	MembersCount int           `json:"members_count"`
+	Messages     GroupMessages `json:"messages"`
+
+	GroupSettings
+}
+
+// GroupSettings are a group's membership settings. GroupMe reports them
+// alongside the other group fields; nil means not reported (or, in an
+// update, unchanged).
+type GroupSettings struct {
+	RequiresApproval *bool         `json:"requires_approval,omitempty"`
+	ShowJoinQuestion *bool         `json:"show_join_question,omitempty"`
+	JoinQuestion     *JoinQuestion `json:"join_question,omitempty"`
+}
+
+// JoinQuestion is the question asked of users requesting to join a group.
+type JoinQuestion struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// GroupMessages summarizes a group's messages.
+type GroupMessages struct {
+	Count                int    `json:"count"`
+	LastMessageID        string `json:"last_message_id"`
+	LastMessageCreatedAt int    `json:"last_message_created_at"`
+}
+
+// MemberCount returns the number of members in the group, as reported by
+// GroupMe or else counted from Members.
+func (g *Group) MemberCount() int {
+	if g.MembersCount > 0 {
+		return g.MembersCount
+	}
+	return len(g.Members)
+}
+
+// LastActivity returns when the group's last message was sent, or when the
+// group was last updated if it has no messages.
+func (g *Group) LastActivity() time.Time {
+	if g.Messages.LastMessageCreatedAt > 0 {
+		return time.Unix(int64(g.Messages.LastMessageCreatedAt), 0).UTC()
+	}
+	return time.Unix(int64(g.UpdatedAt), 0).UTC()
+}
+
+// Member is a member of a GroupMe group.
+type Member struct {
+	ID       string `json:"id"`
+	UserID   string `json:"user_id"`
+	Nickname string `json:"nickname"`
+	ImageURL string `json:"image_url"`
+
+	Muted        bool     `json:"muted"`
+	Autokicked   bool     `json:"autokicked"`
+	AppInstalled bool     `json:"app_installed"`
+	Roles        []string `json:"roles"`
+}
+
+// Member roles.
+const (
+	RoleOwner = "owner"
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// HasRole returns whether the member has a role.
+func (m *Member) HasRole(role string) bool {
+	for _, r := range m.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupUpdate is a change to a group. Only non-nil fields are changed.
+type GroupUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageURL    *string `json:"image_url,omitempty"`
+	OfficeMode  *bool   `json:"office_mode,omitempty"`
+	Share       *bool   `json:"share,omitempty"`
+}
+
+// GetGroup retrieves a group.
+func (c *Client) GetGroup(groupID string) (*Group, error) {
+	return c.GetGroupWithContext(context.Background(), groupID)
+}
+
+// GetGroupWithContext is GetGroup with a context.
+func (c *Client) GetGroupWithContext(ctx context.Context, groupID string) (*Group, error) {
+	var group Group
+	err := c.doRequest(ctx, "groups.get", http.MethodGet, fmt.Sprintf("/groups/%s", groupID), nil, nil, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// UpdateGroup updates a group.
+func (c *Client) UpdateGroup(groupID string, update GroupUpdate) (*Group, error) {
+	return c.UpdateGroupWithContext(context.Background(), groupID, update)
+}
+
+// UpdateGroupWithContext is UpdateGroup with a context.
+func (c *Client) UpdateGroupWithContext(ctx context.Context, groupID string, update GroupUpdate) (*Group, error) {
+	var group Group
+	err := c.doRequest(ctx, "groups.update", http.MethodPost, fmt.Sprintf("/groups/%s/update", groupID), nil, update, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// UpdateGroupSettings changes a group's settings. Only non-nil fields are
+// changed.
+func (c *Client) UpdateGroupSettings(groupID string, settings GroupSettings) (*Group, error) {
+	return c.UpdateGroupSettingsWithContext(context.Background(), groupID, settings)
+}
+
+// UpdateGroupSettingsWithContext is UpdateGroupSettings with a context.
+func (c *Client) UpdateGroupSettingsWithContext(ctx context.Context, groupID string, settings GroupSettings) (*Group, error) {
+	var group Group
+	err := c.doRequest(ctx, "groups.update", http.MethodPost, fmt.Sprintf("/groups/%s/update", groupID), nil, settings, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// GetGroupAvatar downloads a group's avatar image, returning its contents and
+// content type, or ErrNoAvatar if the group has none.
+func (c *Client) GetGroupAvatar(ctx context.Context, groupID string) ([]byte, string, error) {
+	group, err := c.GetGroupWithContext(ctx, groupID)
+	if err != nil {
+		return nil, "", err
+	}
+	if group.ImageURL == "" {
+		return nil, "", ErrNoAvatar
+	}
+
+	return c.DownloadAttachment(ctx, group.ImageURL)
+}
+
+// GetGroupShareURL returns a group's share URL, or ok=false if sharing is
+// disabled for the group.
+func (c *Client) GetGroupShareURL(groupID string) (shareURL string, ok bool, err error) {
+	return c.GetGroupShareURLWithContext(context.Background(), groupID)
+}
+
+// GetGroupShareURLWithContext is GetGroupShareURL with a context.
+func (c *Client) GetGroupShareURLWithContext(ctx context.Context, groupID string) (shareURL string, ok bool, err error) {
+	group, err := c.GetGroupWithContext(ctx, groupID)
+	if err != nil {
+		return "", false, err
+	}
+
+	return group.ShareURL, group.ShareURL != "", nil
+}
+
+// EnsureGroupShareURL returns a group's share URL, enabling sharing for the
+// group first if it is disabled.
+func (c *Client) EnsureGroupShareURL(groupID string) (string, error) {
+	return c.EnsureGroupShareURLWithContext(context.Background(), groupID)
+}
+
+// EnsureGroupShareURLWithContext is EnsureGroupShareURL with a context.
+func (c *Client) EnsureGroupShareURLWithContext(ctx context.Context, groupID string) (string, error) {
+	shareURL, ok, err := c.GetGroupShareURLWithContext(ctx, groupID)
+	if err != nil || ok {
+		return shareURL, err
+	}
+
+	share := true
+	group, err := c.UpdateGroupWithContext(ctx, groupID, GroupUpdate{Share: &share})
+	if err != nil {
+		return "", err
+	}
+
+	return group.ShareURL, nil
+}
+
+// Membership is the authenticated user's membership of a group.
+type Membership struct {
+	GroupID      string
+	GroupName    string
+	MembershipID string
+	Nickname     string
+
+	LastReadMessageID string
+	LastReadAt        int
+}
+
+// ListGroups retrieves a page of the groups the authenticated user is in.
+// Pages start at 1.
+func (c *Client) ListGroups(ctx context.Context, page, perPage int) ([]*Group, error) {
+	// build query params
+	values := url.Values{}
+	if page > 0 {
+		values.Add("page", strconv.Itoa(page))
+	}
+	if perPage > 0 {
+		values.Add("per_page", strconv.Itoa(perPage))
+	}
+
+	var groups []*Group
+	err := c.doRequest(ctx, "groups.list", http.MethodGet, "/groups", values, nil, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// IterateGroups returns a Paginator over all the groups the authenticated user
+// is in.
+func (c *Client) IterateGroups() *Paginator[*Group] {
+	return NewPagePaginator(func(ctx context.Context, page int) ([]*Group, error) {
+		return c.ListGroups(ctx, page, 100)
+	})
+}
+
+// ListMemberships retrieves the authenticated user's membership of every group
+// they are in, along with their read state where GroupMe reports it.
+func (c *Client) ListMemberships(ctx context.Context) ([]Membership, error) {
+	me, err := c.GetMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberships []Membership
+	groups := c.IterateGroups()
+	for groups.Next(ctx) {
+		group := groups.Item()
+
+		membership := Membership{
+			GroupID:           group.ID,
+			GroupName:         group.Name,
+			LastReadMessageID: group.LastReadMessageID,
+			LastReadAt:        group.LastReadAt,
+		}
+		for _, member := range group.Members {
+			if member.UserID == me.ID {
+				membership.MembershipID = member.ID
+				membership.Nickname = member.Nickname
+				break
+			}
+		}
+		memberships = append(memberships, membership)
+	}
+	if err := groups.Err(); err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+// DirectoryOptions filters and paginates SearchPublicGroups.
+type DirectoryOptions struct {
+	// Topic restricts results to groups tagged with it.
+	Topic   string
+	Page    int
+	PerPage int
+}
+
+// MyRole returns the authenticated user's highest role in a group: RoleOwner,
+// RoleAdmin or RoleUser. It returns ErrNotMember if they are not a member.
+func (c *Client) MyRole(ctx context.Context, groupID string) (role string, err error) {
+	me, err := c.GetMe(ctx)
+	if err != nil {
+		return "", err
+	}
+	group, err := c.GetGroupWithContext(ctx, groupID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, member := range group.Members {
+		if member.UserID != me.ID {
+			continue
+		}
+		switch {
+		case member.HasRole(RoleOwner):
+			return RoleOwner, nil
+		case member.HasRole(RoleAdmin):
+			return RoleAdmin, nil
+		default:
+			return RoleUser, nil
+		}
+	}
+
+	return "", ErrNotMember
+}
+
+// SearchPublicGroups searches GroupMe's directory of public groups. GroupMe
+// does not document this endpoint, so it may change without notice. Returned
+// groups carry their ShareURL, which can be used to join them.
+func (c *Client) SearchPublicGroups(ctx context.Context, query string, opts DirectoryOptions) ([]*Group, error) {
+	// build query params
+	values := url.Values{}
+	values.Add("query", query)
+	if opts.Topic != "" {
+		values.Add("topic", opts.Topic)
+	}
+	if opts.Page > 0 {
+		values.Add("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		values.Add("per_page", strconv.Itoa(opts.PerPage))
+	}
+
+	var groups []*Group
+	err := c.doRequest(ctx, "groups.search", http.MethodGet, "/groups/search", values, nil, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// FilterGroupsByTag returns the groups tagged with tag, ignoring case.
+func FilterGroupsByTag(groups []*Group, tag string) []*Group {
+	var filtered []*Group
+	for _, group := range groups {
+		for _, t := range group.Tags {
+			if strings.EqualFold(t, tag) {
+				filtered = append(filtered, group)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+// IteratePublicGroups returns a Paginator over all the results of a directory
+// search, starting at opts.Page.
+func (c *Client) IteratePublicGroups(query string, opts DirectoryOptions) *Paginator[*Group] {
+	first := opts.Page
+	if first < 1 {
+		first = 1
+	}
+
+	return NewPagePaginator(func(ctx context.Context, page int) ([]*Group, error) {
+		opts.Page = first + page - 1
+		return c.SearchPublicGroups(ctx, query, opts)
+	})
+}
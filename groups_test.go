@@ -0,0 +1,62 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestMyRole(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles []string
+		want  string
+	}{
+		{"owner", []string{"admin", "owner"}, RoleOwner},
+		{"admin", []string{"admin"}, RoleAdmin},
+		{"user", []string{"user"}, RoleUser},
+		{"no roles", nil, RoleUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/users/me":
+					writeEnvelope(w, http.StatusOK, User{ID: "me"})
+				case "/groups/g1":
+					writeEnvelope(w, http.StatusOK, Group{ID: "g1", Members: []*Member{
+						{ID: "m1", UserID: "other", Roles: []string{"owner"}},
+						{ID: "m2", UserID: "me", Roles: tt.roles},
+					}})
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+
+			role, err := c.MyRole(context.Background(), "g1")
+			if err != nil {
+				t.Fatalf("MyRole: %v", err)
+			}
+			if role != tt.want {
+				t.Errorf("MyRole = %q, want %q", role, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyRoleNotMember(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/me":
+			writeEnvelope(w, http.StatusOK, User{ID: "me"})
+		default:
+			writeEnvelope(w, http.StatusOK, Group{ID: "g1", Members: []*Member{{UserID: "other", Roles: []string{"owner"}}}})
+		}
+	}))
+
+	if _, err := c.MyRole(context.Background(), "g1"); !errors.Is(err, ErrNotMember) {
+		t.Errorf("err = %v, want ErrNotMember", err)
+	}
+}
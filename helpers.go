@@ -1,12 +1,27 @@
 package groupme
 
-import "net/url"
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
 
-func createURL(baseURL, route string, params string) (URL string, err error) {
+var versionRegexp = regexp.MustCompile(`^v[0-9]+$`)
+
+// createURL joins baseURL, an optional API version (e.g. "v3") and route.
+func createURL(baseURL, version, route string, params string) (URL string, err error) {
 	base, err := url.Parse(baseURL)
 	if err != nil {
 		return "", err
 	}
+	if version != "" {
+		if !versionRegexp.MatchString(version) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+		}
+		base.Path = strings.TrimSuffix(base.Path, "/") + "/" + version
+	}
 	base.Path += route
 
 	if params != "" {
@@ -15,3 +30,20 @@ func createURL(baseURL, route string, params string) (URL string, err error) {
 
 	return base.String(), nil
 }
+
+// splitVersion splits a trailing API version segment off baseURL, if present.
+func splitVersion(baseURL string) (string, string) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL, ""
+	}
+
+	trimmed := strings.TrimSuffix(u.Path, "/")
+	version := path.Base(trimmed)
+	if !versionRegexp.MatchString(version) {
+		return baseURL, ""
+	}
+	u.Path = strings.TrimSuffix(trimmed, version)
+
+	return strings.TrimSuffix(u.String(), "/"), version
+}
@@ -0,0 +1,136 @@
+package groupme
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ImageServiceURL is the URL images are uploaded to.
+const ImageServiceURL = "https://image.groupme.com/pictures"
+
+// UploadImage uploads an image to GroupMe's image service and returns its URL,
+// for use in an image attachment. contentType must be the image's MIME type:
+// "image/jpeg", "image/png" or "image/gif".
+func (c *Client) UploadImage(ctx context.Context, r io.Reader, contentType string) (string, error) {
+	var url string
+	err := c.instrument(ctx, RequestInfo{Endpoint: "images.upload", Method: http.MethodPost}, func(ctx context.Context) (int, int, error) {
+		var status int
+		var err error
+		url, status, err = c.uploadImage(ctx, r, contentType)
+		return status, 0, err
+	})
+	return url, err
+}
+
+func (c *Client) uploadImage(ctx context.Context, r io.Reader, contentType string) (string, int, error) {
+	token, err := c.token(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ImageServiceURL, r)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Access-Token", token)
+	c.setHeaders(req)
+
+	// send request, read body
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return "", 0, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return "", resp.StatusCode, err
+	}
+
+	// exit early on error
+	if err := checkResponse(resp.StatusCode, Meta{}); err != nil {
+		return "", resp.StatusCode, err
+	}
+
+	// parse response
+	var image struct {
+		Payload struct {
+			URL        string `json:"url"`
+			PictureURL string `json:"picture_url"`
+		} `json:"payload"`
+	}
+	err = json.Unmarshal(body, &image)
+	if err != nil {
+		return "", resp.StatusCode, err
+	}
+
+	return image.Payload.PictureURL, resp.StatusCode, nil
+}
+
+// MaxDownloadSize is the largest file DownloadAttachment reads, in bytes.
+const MaxDownloadSize = 100 << 20
+
+// DownloadAttachment downloads a file hosted by GroupMe, such as the URL of an
+// image attachment or a group's ImageURL, returning its contents and content
+// type. URLs on other hosts, such as those of linked images, are rejected with
+// ErrNotGroupMeMedia so that the Client's headers and middleware only ever see
+// GroupMe requests. Files larger than MaxDownloadSize fail with
+// ErrDownloadTooLarge.
+func (c *Client) DownloadAttachment(ctx context.Context, url string) ([]byte, string, error) {
+	var body []byte
+	var contentType string
+	err := c.instrument(ctx, RequestInfo{Endpoint: "images.download", Method: http.MethodGet}, func(ctx context.Context) (int, int, error) {
+		var status int
+		var err error
+		body, contentType, status, err = c.downloadAttachment(ctx, url)
+		return status, 0, err
+	})
+	return body, contentType, err
+}
+
+func (c *Client) downloadAttachment(ctx context.Context, url string) ([]byte, string, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	if !isGroupMeMedia(req.URL) {
+		return nil, "", 0, fmt.Errorf("%w: %s", ErrNotGroupMeMedia, url)
+	}
+	c.setHeaders(req)
+
+	// send request, read body
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxDownloadSize+1))
+	resp.Body.Close()
+	if err != nil {
+		return nil, "", resp.StatusCode, err
+	}
+
+	// exit early on error
+	if err := checkResponse(resp.StatusCode, Meta{}); err != nil {
+		return nil, "", resp.StatusCode, err
+	}
+	if len(body) > MaxDownloadSize {
+		return nil, "", resp.StatusCode, fmt.Errorf("%w: %s is over %d bytes", ErrDownloadTooLarge, url, MaxDownloadSize)
+	}
+
+	return body, resp.Header.Get("Content-Type"), resp.StatusCode, nil
+}
+
+// isGroupMeMedia reports whether u is served by GroupMe, on groupme.com or
+// one of its subdomains.
+func isGroupMeMedia(u *url.URL) bool {
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == "groupme.com" || strings.HasSuffix(host, ".groupme.com")
+}
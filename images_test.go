@@ -0,0 +1,72 @@
+package groupme
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDownloadAttachment(t *testing.T) {
+	c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "i.groupme.com" || r.URL.Path != "/1" {
+			t.Errorf("request = %s%s", r.Host, r.URL.Path)
+		}
+		if r.Header.Get("X-Custom") != "yes" {
+			t.Errorf("headers = %v, want the Client's headers", r.Header)
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("PNGDATA"))
+	}), WithHeader("X-Custom", "yes"))
+
+	body, contentType, err := c.DownloadAttachment(context.Background(), "https://i.groupme.com/1")
+	if err != nil {
+		t.Fatalf("DownloadAttachment: %v", err)
+	}
+	if string(body) != "PNGDATA" || contentType != "image/png" {
+		t.Errorf("DownloadAttachment = %q, %q", body, contentType)
+	}
+}
+
+func TestDownloadAttachmentOtherHosts(t *testing.T) {
+	var requests int
+	c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}), WithHeader("X-Custom", "yes"), WithMiddleware(func(next RequestFunc) RequestFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			t.Errorf("middleware saw %s", req.URL)
+			return next(req)
+		}
+	}))
+
+	for _, url := range []string{
+		"https://example.com/p.png",
+		"https://groupme.com.example.com/p.png",
+		"https://evilgroupme.com/p.png",
+		"ftp://i.groupme.com/1",
+	} {
+		if _, _, err := c.DownloadAttachment(context.Background(), url); !errors.Is(err, ErrNotGroupMeMedia) {
+			t.Errorf("DownloadAttachment(%s): err = %v, want ErrNotGroupMeMedia", url, err)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("requests = %d, want 0", requests)
+	}
+}
+
+func TestDownloadAttachmentTooLarge(t *testing.T) {
+	c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chunk := bytes.Repeat([]byte{0}, 1<<20)
+		for n := 0; n <= MaxDownloadSize; n += len(chunk) {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+		}
+	}))
+
+	_, _, err := c.DownloadAttachment(context.Background(), "https://v.groupme.com/1.mp4")
+	if !errors.Is(err, ErrDownloadTooLarge) {
+		t.Fatalf("err = %v, want ErrDownloadTooLarge", err)
+	}
+}
@@ -0,0 +1,48 @@
+package groupme
+
+import "context"
+
+// MessageIterator pages backwards through a group's message history, newest
+// first, holding at most one page of messages in memory.
+type MessageIterator struct {
+	p *Paginator[*Message]
+}
+
+// IterateMessages returns a MessageIterator over a group's message history.
+func (c *Client) IterateMessages(groupID string) *MessageIterator {
+	fetch := func(ctx context.Context, beforeID string) ([]*Message, error) {
+		messages, err := c.GetMessagesWithContext(ctx, groupID, "100", beforeID, "", "")
+		return messages.Messages, err
+	}
+	cursorOf := func(m *Message) string {
+		return m.ID
+	}
+
+	return &MessageIterator{
+		p: NewCursorPaginator("", fetch, cursorOf).WithPageSize(100),
+	}
+}
+
+// Next advances the iterator to the next message, fetching another page when
+// needed. It returns false when history is exhausted or an error occurs.
+func (it *MessageIterator) Next(ctx context.Context) bool {
+	return it.p.Next(ctx)
+}
+
+// HasMore returns whether older messages may remain. GroupMe reports the start
+// of history with 304 Not Modified, or by returning fewer messages than were
+// asked for; HasMore is false once either has been seen and every fetched
+// message consumed.
+func (it *MessageIterator) HasMore() bool {
+	return it.p.HasMore()
+}
+
+// Message returns the current message.
+func (it *MessageIterator) Message() *Message {
+	return it.p.Item()
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *MessageIterator) Err() error {
+	return it.p.Err()
+}
@@ -0,0 +1,201 @@
+package groupme
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Leaderboard periods.
+const (
+	LeaderboardDay   = "day"
+	LeaderboardWeek  = "week"
+	LeaderboardMonth = "month"
+)
+
+// LikeMessage likes a message. A group's conversation ID is its group ID; for
+// direct messages use ConversationID.
+func (c *Client) LikeMessage(conversationID, messageID string) error {
+	return c.LikeMessageWithContext(context.Background(), conversationID, messageID)
+}
+
+// LikeMessageWithContext is LikeMessage with a context.
+func (c *Client) LikeMessageWithContext(ctx context.Context, conversationID, messageID string) error {
+	return c.doRequest(ctx, "messages.like", http.MethodPost, fmt.Sprintf("/messages/%s/%s/like", conversationID, messageID), nil, nil, nil)
+}
+
+// UnlikeMessage unlikes a message. conversationID is as for LikeMessage.
+func (c *Client) UnlikeMessage(conversationID, messageID string) error {
+	return c.UnlikeMessageWithContext(context.Background(), conversationID, messageID)
+}
+
+// UnlikeMessageWithContext is UnlikeMessage with a context.
+func (c *Client) UnlikeMessageWithContext(ctx context.Context, conversationID, messageID string) error {
+	return c.doRequest(ctx, "messages.unlike", http.MethodPost, fmt.Sprintf("/messages/%s/%s/unlike", conversationID, messageID), nil, nil, nil)
+}
+
+// ToggleLike unlikes a message if it is currently liked, and likes it otherwise.
+func (c *Client) ToggleLike(groupID, messageID string, currentlyLiked bool) error {
+	return c.ToggleLikeWithContext(context.Background(), groupID, messageID, currentlyLiked)
+}
+
+// ToggleLikeWithContext is ToggleLike with a context.
+func (c *Client) ToggleLikeWithContext(ctx context.Context, groupID, messageID string, currentlyLiked bool) error {
+	if currentlyLiked {
+		return c.UnlikeMessageWithContext(ctx, groupID, messageID)
+	}
+	return c.LikeMessageWithContext(ctx, groupID, messageID)
+}
+
+// ToggleMessageLike toggles a like on a message, using its FavoritedBy to
+// determine whether userID currently likes it.
+func (c *Client) ToggleMessageLike(message *Message, userID string) error {
+	return c.ToggleMessageLikeWithContext(context.Background(), message, userID)
+}
+
+// ToggleMessageLikeWithContext is ToggleMessageLike with a context.
+func (c *Client) ToggleMessageLikeWithContext(ctx context.Context, message *Message, userID string) error {
+	return c.ToggleLikeWithContext(ctx, message.GroupID, message.ID, message.IsLikedBy(userID))
+}
+
+// IsLikedBy returns whether a user has liked the message.
+func (m *Message) IsLikedBy(userID string) bool {
+	for _, id := range m.FavoritedBy {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// AllLikes pages through a group's full history and returns a map of message
+// ID to the IDs of the users who liked it. Messages without likes are omitted.
+func (c *Client) AllLikes(ctx context.Context, groupID string) (map[string][]string, error) {
+	likes := map[string][]string{}
+
+	it := c.IterateMessages(groupID)
+	for it.Next(ctx) {
+		message := it.Message()
+		if len(message.FavoritedBy) > 0 {
+			likes[message.ID] = message.FavoritedBy
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return likes, nil
+}
+
+// likeConcurrency is the number of concurrent requests made by LikeAllSince.
+const likeConcurrency = 4
+
+// LikeAllSince likes every message in a group created after since that
+// myUserID has not already liked, returning how many it liked. History is
+// walked newest first with IterateMessages, liking messages as their pages
+// arrive, and the walk stops at the first message not after since. It stops
+// at the first failed like or when ctx is done, returning the error along with
+// the number liked so far.
+func (c *Client) LikeAllSince(ctx context.Context, groupID, myUserID string, since time.Time) (likedCount int, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		liked    int64
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, likeConcurrency)
+	it := c.IterateMessages(groupID)
+loop:
+	for it.Next(ctx) {
+		message := it.Message()
+		if !message.CreatedAtTime().After(since) {
+			break
+		}
+		if message.IsLikedBy(myUserID) {
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := c.LikeMessageWithContext(ctx, groupID, id); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			atomic.AddInt64(&liked, 1)
+		}(message.ID)
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = it.Err()
+	}
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+
+	return int(liked), firstErr
+}
+
+// GetLeaderboard retrieves a group's most liked messages over a period
+// (LeaderboardDay, LeaderboardWeek or LeaderboardMonth), most liked first.
+func (c *Client) GetLeaderboard(groupID, period string) ([]*Message, error) {
+	return c.GetLeaderboardWithContext(context.Background(), groupID, period)
+}
+
+// GetLeaderboardWithContext is GetLeaderboard with a context.
+func (c *Client) GetLeaderboardWithContext(ctx context.Context, groupID, period string) ([]*Message, error) {
+	// build query params
+	values := url.Values{}
+	values.Add("period", period)
+
+	var leaderboard struct {
+		Messages []*Message `json:"messages"`
+	}
+	err := c.doRequest(ctx, "groups.leaderboard", http.MethodGet, fmt.Sprintf("/groups/%s/likes", groupID), values, nil, &leaderboard)
+	if err != nil {
+		return nil, err
+	}
+
+	return leaderboard.Messages, nil
+}
+
+// LeaderboardRank returns the 1-based rank of a message on a group's
+// leaderboard for a period, and the number of messages on it. If the message
+// is not on the leaderboard, ErrNotOnLeaderboard is returned with the total.
+func (c *Client) LeaderboardRank(groupID, messageID, period string) (rank int, total int, err error) {
+	return c.LeaderboardRankWithContext(context.Background(), groupID, messageID, period)
+}
+
+// LeaderboardRankWithContext is LeaderboardRank with a context.
+func (c *Client) LeaderboardRankWithContext(ctx context.Context, groupID, messageID, period string) (rank int, total int, err error) {
+	messages, err := c.GetLeaderboardWithContext(ctx, groupID, period)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i, message := range messages {
+		if message.ID == messageID {
+			return i + 1, len(messages), nil
+		}
+	}
+
+	return 0, len(messages), ErrNotOnLeaderboard
+}
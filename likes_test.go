@@ -0,0 +1,272 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestToggleLike(t *testing.T) {
+	tests := []struct {
+		name           string
+		currentlyLiked bool
+		want           string
+	}{
+		{"not liked", false, "/messages/g1/m1/like"},
+		{"liked", true, "/messages/g1/m1/unlike"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("method = %s, want POST", r.Method)
+				}
+				got = append(got, r.URL.Path)
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			if err := c.ToggleLike("g1", "m1", tt.currentlyLiked); err != nil {
+				t.Fatalf("ToggleLike: %v", err)
+			}
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("requests = %v, want [%s]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToggleMessageLike(t *testing.T) {
+	var got string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Path
+	}))
+
+	message := &Message{ID: "m1", GroupID: "g1", FavoritedBy: []string{"u2", "u1"}}
+	if err := c.ToggleMessageLike(message, "u1"); err != nil {
+		t.Fatalf("ToggleMessageLike: %v", err)
+	}
+	if want := "/messages/g1/m1/unlike"; got != want {
+		t.Errorf("liked message: request = %s, want %s", got, want)
+	}
+
+	if err := c.ToggleMessageLike(message, "u3"); err != nil {
+		t.Fatalf("ToggleMessageLike: %v", err)
+	}
+	if want := "/messages/g1/m1/like"; got != want {
+		t.Errorf("unliked message: request = %s, want %s", got, want)
+	}
+}
+
+func TestToggleLikeError(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMetaError(w, http.StatusNotFound, "message not found")
+	}))
+
+	err := c.ToggleLike("g1", "m1", false)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAllLikes(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	h.messages[0].FavoritedBy = []string{"u1"}
+	h.messages[149].FavoritedBy = []string{"u1", "u2"}
+	h.messages[249].FavoritedBy = []string{"u3"}
+	c := newTestClient(t, h)
+
+	likes, err := c.AllLikes(context.Background(), "g1")
+	if err != nil {
+		t.Fatalf("AllLikes: %v", err)
+	}
+
+	want := map[string][]string{
+		"1":   {"u1"},
+		"150": {"u1", "u2"},
+		"250": {"u3"},
+	}
+	if !reflect.DeepEqual(likes, want) {
+		t.Errorf("AllLikes = %v, want %v", likes, want)
+	}
+	// two full pages and a short one that ends the history
+	if got := h.requestCount(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+}
+
+func TestAllLikesCanceled(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.AllLikes(ctx, "g1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if got := h.requestCount(); got != 0 {
+		t.Errorf("requests = %d, want 0", got)
+	}
+}
+
+func TestAllLikesError(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	h.failOn = 2
+	c := newTestClient(t, h)
+
+	if _, err := c.AllLikes(context.Background(), "g1"); !errors.Is(err, ErrInternalServerError) {
+		t.Fatalf("err = %v, want ErrInternalServerError", err)
+	}
+}
+
+// likeHistory is a fakeHistory that also records likes.
+type likeHistory struct {
+	*fakeHistory
+
+	mu    sync.Mutex
+	liked []string
+}
+
+func (h *likeHistory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if id, ok := strings.CutPrefix(r.URL.Path, "/messages/g1/"); ok {
+		h.mu.Lock()
+		h.liked = append(h.liked, strings.TrimSuffix(id, "/like"))
+		h.mu.Unlock()
+		return
+	}
+	h.fakeHistory.ServeHTTP(w, r)
+}
+
+func TestLikeAllSince(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h := &likeHistory{fakeHistory: newFakeHistory(10, start)}
+	h.messages[6].FavoritedBy = []string{"me"}
+	c := newTestClient(t, h)
+
+	// message 5 was created exactly at since, so it is not liked
+	since := h.messages[4].CreatedAtTime()
+	n, err := c.LikeAllSince(context.Background(), "g1", "me", since)
+	if err != nil {
+		t.Fatalf("LikeAllSince: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("liked %d, want 4", n)
+	}
+
+	sort.Strings(h.liked)
+	if want := []string{"10", "6", "8", "9"}; !reflect.DeepEqual(h.liked, want) {
+		t.Errorf("liked %v, want %v", h.liked, want)
+	}
+}
+
+func TestLikeAllSinceStopsOnError(t *testing.T) {
+	h := newFakeHistory(10, time.Unix(1700000000, 0))
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/like") {
+			writeMetaError(w, http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+
+	n, err := c.LikeAllSince(context.Background(), "g1", "me", time.Unix(0, 0))
+	if !errors.Is(err, ErrForbidden) || n != 0 {
+		t.Errorf("LikeAllSince = %d, %v; want 0, ErrForbidden", n, err)
+	}
+}
+
+func TestLikeAllSinceStopsAtSince(t *testing.T) {
+	h := &likeHistory{fakeHistory: newFakeHistory(250, time.Unix(1700000000, 0))}
+	c := newTestClient(t, h)
+
+	n, err := c.LikeAllSince(context.Background(), "g1", "me", h.messages[209].CreatedAtTime())
+	if err != nil {
+		t.Fatalf("LikeAllSince: %v", err)
+	}
+	if n != 40 || len(h.liked) != 40 {
+		t.Errorf("liked %d (%d requests), want 40", n, len(h.liked))
+	}
+	// the first page reaches since, so no older page is fetched
+	if got := h.requestCount(); got != 1 {
+		t.Errorf("pages fetched = %d, want 1", got)
+	}
+}
+
+func TestLikeAllSincePageError(t *testing.T) {
+	h := &likeHistory{fakeHistory: newFakeHistory(250, time.Unix(1700000000, 0))}
+	h.failOn = 2
+	c := newTestClient(t, h)
+
+	// the first page's messages are liked before the second page fails
+	n, err := c.LikeAllSince(context.Background(), "g1", "me", time.Unix(0, 0))
+	if !errors.Is(err, ErrInternalServerError) || n != 100 {
+		t.Errorf("LikeAllSince = %d, %v; want 100, ErrInternalServerError", n, err)
+	}
+}
+
+func leaderboardServer(t *testing.T, messages []*Message) *Client {
+	t.Helper()
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/groups/g1/likes" || r.URL.Query().Get("period") != LeaderboardWeek {
+			t.Errorf("request = %s, want /groups/g1/likes?period=week", r.URL)
+		}
+		writeEnvelope(w, http.StatusOK, map[string]interface{}{"messages": messages})
+	}))
+}
+
+func TestLeaderboardRank(t *testing.T) {
+	// m2 and m3 tie on likes; the leaderboard's order decides their rank
+	c := leaderboardServer(t, []*Message{
+		{ID: "m1", FavoritedBy: []string{"u1", "u2", "u3"}},
+		{ID: "m3", FavoritedBy: []string{"u1", "u2"}},
+		{ID: "m2", FavoritedBy: []string{"u2", "u3"}},
+		{ID: "m4", FavoritedBy: []string{"u1"}},
+	})
+
+	tests := []struct {
+		messageID string
+		want      int
+	}{
+		{"m1", 1},
+		{"m3", 2},
+		{"m2", 3},
+		{"m4", 4},
+	}
+	for _, tt := range tests {
+		rank, total, err := c.LeaderboardRank("g1", tt.messageID, LeaderboardWeek)
+		if err != nil {
+			t.Fatalf("LeaderboardRank(%s): %v", tt.messageID, err)
+		}
+		if rank != tt.want || total != 4 {
+			t.Errorf("LeaderboardRank(%s) = %d of %d, want %d of 4", tt.messageID, rank, total, tt.want)
+		}
+	}
+}
+
+func TestLeaderboardRankNotOnLeaderboard(t *testing.T) {
+	c := leaderboardServer(t, []*Message{{ID: "m1"}, {ID: "m2"}})
+
+	rank, total, err := c.LeaderboardRank("g1", "m9", LeaderboardWeek)
+	if !errors.Is(err, ErrNotOnLeaderboard) {
+		t.Fatalf("LeaderboardRank error = %v, want ErrNotOnLeaderboard", err)
+	}
+	if rank != 0 || total != 2 {
+		t.Errorf("LeaderboardRank = %d of %d, want 0 of 2", rank, total)
+	}
+}
+
+func TestLeaderboardRankEmpty(t *testing.T) {
+	c := leaderboardServer(t, nil)
+
+	rank, total, err := c.LeaderboardRank("g1", "m1", LeaderboardWeek)
+	if !errors.Is(err, ErrNotOnLeaderboard) || rank != 0 || total != 0 {
+		t.Errorf("LeaderboardRank = %d, %d, %v, want 0, 0, ErrNotOnLeaderboard", rank, total, err)
+	}
+}
@@ -0,0 +1,133 @@
+package groupme
+
+import (
+	"context"
+	"strings"
+)
+
+// MessageMatcher is a rule for classifying messages, such as those received by
+// a CallbackHandler or a MessageStream.
+type MessageMatcher func(*Message) bool
+
+// Match returns whether m matches the rule.
+func (mm MessageMatcher) Match(m *Message) bool {
+	return mm(m)
+}
+
+// TextContains matches messages whose text contains substr, ignoring case.
+func TextContains(substr string) MessageMatcher {
+	substr = strings.ToLower(substr)
+	return func(m *Message) bool {
+		return strings.Contains(strings.ToLower(m.Text), substr)
+	}
+}
+
+// FromUser matches messages sent by a user.
+func FromUser(userID string) MessageMatcher {
+	return func(m *Message) bool {
+		return m.UserID == userID
+	}
+}
+
+// HasAttachmentType matches messages with an attachment of a type.
+func HasAttachmentType(attachmentType string) MessageMatcher {
+	return func(m *Message) bool {
+		for _, a := range m.Attachments {
+			if a.Type == attachmentType {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MentionsMe matches messages that mention a user, directly or by mentioning
+// everyone.
+func MentionsMe(userID string) MessageMatcher {
+	return func(m *Message) bool {
+		for _, a := range m.Attachments {
+			if a.Type != MentionsAttachment {
+				continue
+			}
+			for _, id := range a.UserIDs {
+				if id == userID || id == EveryoneUserID {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// MatchAll matches messages that match every one of matchers.
+func MatchAll(matchers ...MessageMatcher) MessageMatcher {
+	return func(m *Message) bool {
+		for _, mm := range matchers {
+			if !mm(m) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchAny matches messages that match at least one of matchers.
+func MatchAny(matchers ...MessageMatcher) MessageMatcher {
+	return func(m *Message) bool {
+		for _, mm := range matchers {
+			if mm(m) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchNot matches messages that do not match mm.
+func MatchNot(mm MessageMatcher) MessageMatcher {
+	return func(m *Message) bool {
+		return !mm(m)
+	}
+}
+
+// Filter returns the messages that match mm, in the order given.
+func (mm MessageMatcher) Filter(messages []*Message) []*Message {
+	var matched []*Message
+	for _, m := range messages {
+		if mm(m) {
+			matched = append(matched, m)
+		}
+	}
+	return matched
+}
+
+// FilterChan passes on the messages received from in that match mm. The
+// returned channel is closed once in is or ctx is done, whichever is first.
+func (mm MessageMatcher) FilterChan(ctx context.Context, in <-chan *Message) <-chan *Message {
+	out := make(chan *Message)
+	go func() {
+		defer close(out)
+		for {
+			var m *Message
+			var ok bool
+			select {
+			case m, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			if !mm(m) {
+				continue
+			}
+
+			select {
+			case out <- m:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
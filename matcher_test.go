@@ -0,0 +1,112 @@
+package groupme
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMessageMatchers(t *testing.T) {
+	alert := &Message{ID: "1", UserID: "u1", Text: "Structure FIRE on Main St"}
+	photo := &Message{ID: "2", UserID: "u2", Text: "fire photo", Attachments: []Attachment{NewImageAttachment("https://i.groupme.com/1")}}
+	mention := &Message{ID: "3", UserID: "u2", Text: "@all drill tonight", Attachments: []Attachment{NewEveryoneMention()}}
+	direct := &Message{ID: "4", UserID: "u3", Text: "@Alice", Attachments: []Attachment{{Type: MentionsAttachment, UserIDs: []string{"u1"}}}}
+	messages := []*Message{alert, photo, mention, direct}
+
+	tests := []struct {
+		name    string
+		matcher MessageMatcher
+		want    []*Message
+	}{
+		{"TextContains", TextContains("fire"), []*Message{alert, photo}},
+		{"FromUser", FromUser("u2"), []*Message{photo, mention}},
+		{"HasAttachmentType", HasAttachmentType(ImageAttachment), []*Message{photo}},
+		{"MentionsMe", MentionsMe("u1"), []*Message{mention, direct}},
+		{"MatchAll", MatchAll(TextContains("fire"), FromUser("u2")), []*Message{photo}},
+		{"MatchAll empty", MatchAll(), messages},
+		{"MatchAny", MatchAny(FromUser("u1"), FromUser("u3")), []*Message{alert, direct}},
+		{"MatchAny empty", MatchAny(), nil},
+		{"MatchNot", MatchNot(TextContains("fire")), []*Message{mention, direct}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Filter(messages); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter = %v, want %v", messageIDs(got), messageIDs(tt.want))
+			}
+			for _, m := range messages {
+				if tt.matcher.Match(m) != tt.matcher(m) {
+					t.Errorf("Match(%s) disagrees with the matcher", m.ID)
+				}
+			}
+		})
+	}
+}
+
+func messageIDs(messages []*Message) []string {
+	var ids []string
+	for _, m := range messages {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func TestFilterChan(t *testing.T) {
+	in := make(chan *Message)
+	go func() {
+		defer close(in)
+		for _, id := range []string{"1", "2", "3", "4"} {
+			in <- &Message{ID: id, UserID: "u" + id}
+		}
+	}()
+
+	out := MatchAny(FromUser("u1"), FromUser("u3")).FilterChan(context.Background(), in)
+	var got []*Message
+	for m := range out {
+		got = append(got, m)
+	}
+	if ids := messageIDs(got); !reflect.DeepEqual(ids, []string{"1", "3"}) {
+		t.Errorf("FilterChan passed %v, want [1 3]", ids)
+	}
+}
+
+func TestFilterChanCanceled(t *testing.T) {
+	tests := []struct {
+		name string
+		send bool
+	}{
+		// the goroutine is waiting for a message
+		{"receiving", false},
+		// the goroutine is waiting to pass on a message nobody reads
+		{"sending", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan *Message, 1)
+			if tt.send {
+				in <- &Message{ID: "1"}
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			out := MatchAll().FilterChan(ctx, in)
+			if tt.send {
+				// give the goroutine time to block on out
+				time.Sleep(10 * time.Millisecond)
+			}
+			cancel()
+
+			timeout := time.After(time.Second)
+			for {
+				select {
+				case _, ok := <-out:
+					if !ok {
+						return
+					}
+				case <-timeout:
+					t.Fatal("out not closed after cancel")
+				}
+			}
+		})
+	}
+}
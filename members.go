@@ -0,0 +1,174 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// NewMember is a user to add to a group, identified by exactly one of UserID,
+// PhoneNumber or Email.
+type NewMember struct {
+	Nickname    string `json:"nickname"`
+	UserID      string `json:"user_id,omitempty"`
+	PhoneNumber string `json:"phone_number,omitempty"`
+	Email       string `json:"email,omitempty"`
+
+	// GUID identifies the member in the add results. One is generated by
+	// AddMembers if empty.
+	GUID string `json:"guid"`
+}
+
+// Add results statuses.
+const (
+	AddStatusAdded    = "added"
+	AddStatusNotAdded = "not_added"
+)
+
+// AddResult is the outcome of adding one member.
+type AddResult struct {
+	Request NewMember
+	Status  string
+
+	// Member is the new membership, if the member was added.
+	Member *Member
+
+	// Reason explains why the member was not added, as far as is known.
+	Reason string
+}
+
+// AddMembers asks GroupMe to add members to a group, which happens
+// asynchronously. It fills in missing GUIDs in members and returns the ID to
+// pass to GetAddResults along with them.
+func (c *Client) AddMembers(ctx context.Context, groupID string, members []NewMember) (string, error) {
+	for i := range members {
+		if members[i].GUID == "" {
+			members[i].GUID = NewSourceGUID()
+		}
+	}
+
+	payload := struct {
+		Members []NewMember `json:"members"`
+	}{members}
+
+	var resp struct {
+		ResultsID string `json:"results_id"`
+	}
+	err := c.doRequest(ctx, "members.add", http.MethodPost, fmt.Sprintf("/groups/%s/members/add", groupID), nil, payload, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.ResultsID, nil
+}
+
+// GetAddResults retrieves the outcome of an AddMembers request for each of the
+// members it was given, in the same order. It returns ErrResultsPending until
+// GroupMe has processed the request; poll again later. GroupMe lists only the
+// members it added, without saying why others were not (they may already be
+// in the group, or not be reachable at the given number or address), so these
+// are reported as AddStatusNotAdded with a generic Reason.
+func (c *Client) GetAddResults(ctx context.Context, groupID, resultsID string, requested []NewMember) ([]AddResult, error) {
+	var resp struct {
+		Members []struct {
+			Member
+			GUID string `json:"guid"`
+		} `json:"members"`
+	}
+	err := c.doRequest(ctx, "members.results", http.MethodGet, fmt.Sprintf("/groups/%s/members/results/%s", groupID, resultsID), nil, nil, &resp)
+	if err != nil {
+		if errors.Is(err, ErrServiceUnavailable) {
+			return nil, ErrResultsPending
+		}
+		return nil, err
+	}
+
+	added := map[string]*Member{}
+	for i := range resp.Members {
+		added[resp.Members[i].GUID] = &resp.Members[i].Member
+	}
+
+	results := make([]AddResult, 0, len(requested))
+	for _, member := range requested {
+		result := AddResult{Request: member}
+		if m, ok := added[member.GUID]; ok {
+			result.Status = AddStatusAdded
+			result.Member = m
+		} else {
+			result.Status = AddStatusNotAdded
+			result.Reason = "not in GroupMe's results: already a member or not reachable"
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// JoinRequest is a request to join a group that requires approval.
+type JoinRequest struct {
+	ID        string
+	User      UserRef
+	Answer    string
+	CreatedAt int
+}
+
+// ListJoinRequests retrieves the pending requests to join a group. GroupMe
+// does not document the join request endpoints, so they may change without
+// notice.
+func (c *Client) ListJoinRequests(ctx context.Context, groupID string) ([]JoinRequest, error) {
+	var resp []struct {
+		ID        string `json:"id"`
+		UserID    string `json:"user_id"`
+		Nickname  string `json:"nickname"`
+		ImageURL  string `json:"image_url"`
+		CreatedAt int    `json:"created_at"`
+		Answer    struct {
+			Response string `json:"response"`
+		} `json:"question_answer"`
+	}
+	err := c.doRequest(ctx, "members.pending", http.MethodGet, fmt.Sprintf("/groups/%s/pending_memberships", groupID), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]JoinRequest, 0, len(resp))
+	for _, r := range resp {
+		requests = append(requests, JoinRequest{
+			ID:        r.ID,
+			User:      UserRef{ID: r.UserID, Name: r.Nickname, AvatarURL: r.ImageURL},
+			Answer:    r.Answer.Response,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	return requests, nil
+}
+
+// ApproveJoinRequest approves a request to join a group.
+func (c *Client) ApproveJoinRequest(groupID, requestID string) error {
+	return c.ApproveJoinRequestWithContext(context.Background(), groupID, requestID)
+}
+
+// ApproveJoinRequestWithContext is ApproveJoinRequest with a context.
+func (c *Client) ApproveJoinRequestWithContext(ctx context.Context, groupID, requestID string) error {
+	return c.answerJoinRequest(ctx, groupID, requestID, true)
+}
+
+// DenyJoinRequest denies a request to join a group.
+func (c *Client) DenyJoinRequest(groupID, requestID string) error {
+	return c.DenyJoinRequestWithContext(context.Background(), groupID, requestID)
+}
+
+// DenyJoinRequestWithContext is DenyJoinRequest with a context.
+func (c *Client) DenyJoinRequestWithContext(ctx context.Context, groupID, requestID string) error {
+	return c.answerJoinRequest(ctx, groupID, requestID, false)
+}
+
+func (c *Client) answerJoinRequest(ctx context.Context, groupID, requestID string, approve bool) error {
+	payload := struct {
+		Approval bool `json:"approval"`
+	}{approve}
+
+	return c.doRequest(ctx, "members.approval", http.MethodPost, fmt.Sprintf("/groups/%s/members/%s/approval", groupID, requestID), nil, payload, nil)
+}
@@ -0,0 +1,113 @@
+package groupme
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAddMembers(t *testing.T) {
+	var got struct {
+		Members []NewMember `json:"members"`
+	}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/groups/g1/members/add" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		writeEnvelope(w, http.StatusAccepted, map[string]string{"results_id": "r1"})
+	}))
+
+	members := []NewMember{
+		{Nickname: "Alice", UserID: "u1", GUID: "mine"},
+		{Nickname: "Bob", Email: "bob@example.com"},
+	}
+	resultsID, err := c.AddMembers(context.Background(), "g1", members)
+	if err != nil {
+		t.Fatalf("AddMembers: %v", err)
+	}
+	if resultsID != "r1" {
+		t.Errorf("resultsID = %q, want r1", resultsID)
+	}
+	if members[0].GUID != "mine" || members[1].GUID == "" {
+		t.Errorf("GUIDs = %q, %q; want mine and a generated one", members[0].GUID, members[1].GUID)
+	}
+	if len(got.Members) != 2 || got.Members[1].GUID != members[1].GUID {
+		t.Errorf("sent %+v, want the members with their GUIDs", got.Members)
+	}
+}
+
+func TestGetAddResultsPartialFailure(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/groups/g1/members/results/r1" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		writeEnvelope(w, http.StatusOK, map[string]interface{}{
+			"members": []map[string]string{
+				{"id": "m3", "user_id": "u3", "nickname": "Carol", "guid": "c"},
+				{"id": "m1", "user_id": "u1", "nickname": "Alice", "guid": "a"},
+			},
+		})
+	}))
+
+	requested := []NewMember{
+		{Nickname: "Alice", UserID: "u1", GUID: "a"},
+		{Nickname: "Bob", PhoneNumber: "+1 5555550100", GUID: "b"},
+		{Nickname: "Carol", Email: "carol@example.com", GUID: "c"},
+	}
+	results, err := c.GetAddResults(context.Background(), "g1", "r1", requested)
+	if err != nil {
+		t.Fatalf("GetAddResults: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	// results follow the requested order, not GroupMe's
+	for i, want := range []struct {
+		status, memberID string
+	}{
+		{AddStatusAdded, "m1"},
+		{AddStatusNotAdded, ""},
+		{AddStatusAdded, "m3"},
+	} {
+		r := results[i]
+		if r.Request != requested[i] || r.Status != want.status {
+			t.Errorf("results[%d] = %+v, want %s for %+v", i, r, want.status, requested[i])
+			continue
+		}
+		if want.memberID == "" {
+			if r.Member != nil || r.Reason == "" {
+				t.Errorf("results[%d]: Member = %v, Reason = %q; want no member and a reason", i, r.Member, r.Reason)
+			}
+		} else if r.Member == nil || r.Member.ID != want.memberID {
+			t.Errorf("results[%d].Member = %+v, want ID %s", i, r.Member, want.memberID)
+		}
+	}
+}
+
+func TestGetAddResultsPending(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMetaError(w, http.StatusServiceUnavailable)
+	}))
+
+	_, err := c.GetAddResults(context.Background(), "g1", "r1", nil)
+	if !errors.Is(err, ErrResultsPending) {
+		t.Fatalf("err = %v, want ErrResultsPending", err)
+	}
+}
+
+func TestGetAddResultsError(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMetaError(w, http.StatusNotFound, "results not found")
+	}))
+
+	_, err := c.GetAddResults(context.Background(), "g1", "r1", nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+}
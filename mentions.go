@@ -0,0 +1,104 @@
+package groupme
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// ComputeLoci returns the [offset, length] of each substring within text,
+// measured in UTF-16 code units as GroupMe expects. Each substring is located
+// after the end of the previous one, so repeated names map to successive
+// occurrences.
+func ComputeLoci(text string, substrings []string) ([][2]int, error) {
+	loci := make([][2]int, 0, len(substrings))
+
+	var pos int
+	for _, sub := range substrings {
+		i := strings.Index(text[pos:], sub)
+		if i < 0 {
+			return nil, fmt.Errorf("groupme: %q not found in message text", sub)
+		}
+		start := pos + i
+
+		loci = append(loci, [2]int{utf16Len(text[:start]), utf16Len(sub)})
+		pos = start + len(sub)
+	}
+
+	return loci, nil
+}
+
+// utf16Len returns the length of s in UTF-16 code units.
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
+
+// NewMentionsAttachment returns a mentions Attachment in which userIDs[i] is
+// mentioned by substrings[i] within text.
+func NewMentionsAttachment(text string, userIDs, substrings []string) (Attachment, error) {
+	if len(userIDs) != len(substrings) {
+		return Attachment{}, fmt.Errorf("groupme: %d user IDs for %d mentions", len(userIDs), len(substrings))
+	}
+
+	loci, err := ComputeLoci(text, substrings)
+	if err != nil {
+		return Attachment{}, err
+	}
+
+	a := Attachment{
+		Type:    MentionsAttachment,
+		UserIDs: userIDs,
+	}
+	for _, l := range loci {
+		a.Loci = append(a.Loci, []int{l[0], l[1]})
+	}
+
+	return a, nil
+}
+
+// CreateMentionMessage creates a message for a group that mentions userIDs[i]
+// at substrings[i] within text.
+func (c *Client) CreateMentionMessage(groupID, sourceGUID, text string, userIDs, substrings []string) (CreateMessageResponse, error) {
+	return c.CreateMentionMessageWithContext(context.Background(), groupID, sourceGUID, text, userIDs, substrings)
+}
+
+// CreateMentionMessageWithContext is CreateMentionMessage with a context.
+func (c *Client) CreateMentionMessageWithContext(ctx context.Context, groupID, sourceGUID, text string, userIDs, substrings []string) (CreateMessageResponse, error) {
+	mentions, err := NewMentionsAttachment(text, userIDs, substrings)
+	if err != nil {
+		return CreateMessageResponse{}, err
+	}
+
+	return c.createMessage(ctx, groupID, sourceGUID, text, []Attachment{mentions})
+}
+
+// EveryoneUserID is the user ID a mentions attachment uses to mention the
+// whole group.
+const EveryoneUserID = "0"
+
+// NewEveryoneMention returns a mentions Attachment that mentions everyone in
+// the group. Not every group permits everyone mentions, and GroupMe may
+// reject a message carrying one.
+func NewEveryoneMention() Attachment {
+	return Attachment{
+		Type:    MentionsAttachment,
+		UserIDs: []string{EveryoneUserID},
+		Loci:    [][]int{{0, 0}},
+	}
+}
+
+// MentionsEveryone returns whether the message mentions the whole group.
+func (m *Message) MentionsEveryone() bool {
+	for _, a := range m.Attachments {
+		if a.Type != MentionsAttachment {
+			continue
+		}
+		for _, id := range a.UserIDs {
+			if id == EveryoneUserID {
+				return true
+			}
+		}
+	}
+	return false
+}
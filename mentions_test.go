@@ -0,0 +1,88 @@
+package groupme
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestComputeLoci(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		substrings []string
+		want       [][2]int
+	}{
+		{
+			name:       "ascii",
+			text:       "hi @Bob and @Al",
+			substrings: []string{"@Bob", "@Al"},
+			want:       [][2]int{{3, 4}, {12, 3}},
+		},
+		{
+			// "👋" is a surrogate pair: two UTF-16 code units, four UTF-8 bytes
+			name:       "emoji before mention",
+			text:       "Hey 👋 @Zoë and @José",
+			substrings: []string{"@Zoë", "@José"},
+			want:       [][2]int{{7, 4}, {16, 5}},
+		},
+		{
+			name:       "emoji in name",
+			text:       "@🌸Lily🌸 ping",
+			substrings: []string{"@🌸Lily🌸"},
+			want:       [][2]int{{0, 9}},
+		},
+		{
+			name:       "repeated name",
+			text:       "@Renée, @Renée!",
+			substrings: []string{"@Renée", "@Renée"},
+			want:       [][2]int{{0, 6}, {8, 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComputeLoci(tt.text, tt.substrings)
+			if err != nil {
+				t.Fatalf("ComputeLoci: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ComputeLoci = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeLociMissing(t *testing.T) {
+	if _, err := ComputeLoci("hi @Bob", []string{"@Bob", "@Bob"}); err == nil {
+		t.Fatal("ComputeLoci with a second, absent occurrence: want error")
+	}
+}
+
+func TestCreateMentionMessage(t *testing.T) {
+	var payload CreateMessagePayload
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		writeEnvelope(w, http.StatusCreated, CreateMessageResponse{Message: &Message{ID: "m1"}})
+	}))
+
+	_, err := c.CreateMentionMessage("g1", "guid", "🎉 @Zoë", []string{"u1"}, []string{"@Zoë"})
+	if err != nil {
+		t.Fatalf("CreateMentionMessage: %v", err)
+	}
+
+	want := []Attachment{{Type: MentionsAttachment, UserIDs: []string{"u1"}, Loci: [][]int{{3, 4}}}}
+	if got := payload.Message.Attachments; len(got) != 1 || got[0].Type != want[0].Type ||
+		!reflect.DeepEqual(got[0].UserIDs, want[0].UserIDs) || !reflect.DeepEqual(got[0].Loci, want[0].Loci) {
+		t.Errorf("attachments = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewMentionsAttachmentMismatch(t *testing.T) {
+	if _, err := NewMentionsAttachment("@a @b", []string{"u1"}, []string{"@a", "@b"}); err == nil {
+		t.Fatal("NewMentionsAttachment with mismatched lengths: want error")
+	}
+}
@@ -1,13 +1,16 @@
 package groupme
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Message is a GroupMe message.
@@ -16,7 +19,9 @@ type Message struct {
 	SourceGUID string `json:"source_guid"`
 	UserID     string `json:"user_id"`
 	GroupID    string `json:"group_id"`
-	SenderID   string `json:"sender_id"`
+	// set on direct messages instead of GroupID
+	RecipientID string `json:"recipient_id,omitempty"`
+	SenderID    string `json:"sender_id"`
 
 	Name      string `json:"name"`
 	AvatarURL string `json:"avatar_url"`
@@ -31,6 +36,107 @@ type Message struct {
 	FavoritedBy []string     `json:"favorited_by"`
 	Attachments []Attachment `json:"attachments"`
 	Event       Event        `json:"event"`
+	Reactions   []Reaction   `json:"reactions,omitempty"`
+
+	// set on messages that have been deleted
+	DeletedAt     int    `json:"deleted_at,omitempty"`
+	DeletionActor string `json:"deletion_actor,omitempty"`
+
+	// set on pinned messages
+	PinnedAt int `json:"pinned_at,omitempty"`
+
+	// Raw is the original JSON of the message, if the Client keeps it.
+	Raw json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the message in GroupMe's shape: favorited_by and
+// attachments are always arrays, and event is omitted when there is none.
+func (m Message) MarshalJSON() ([]byte, error) {
+	type message Message
+	out := struct {
+		message
+		Event *Event `json:"event,omitempty"`
+	}{message: message(m)}
+
+	if out.FavoritedBy == nil {
+		out.FavoritedBy = []string{}
+	}
+	if out.Attachments == nil {
+		out.Attachments = []Attachment{}
+	}
+	if m.Event.Type != "" || m.Event.Exists() {
+		out.Event = &m.Event
+	}
+
+	return json.Marshal(out)
+}
+
+// Reaction is an emoji reaction to a message and the users who reacted with it.
+type Reaction struct {
+	Type    string   `json:"type"`
+	Code    string   `json:"code"`
+	UserIDs []string `json:"user_ids"`
+}
+
+// ReactionCount returns the number of users who reacted to the message with
+// code.
+func (m *Message) ReactionCount(code string) int {
+	return len(m.ReactionUsers(code))
+}
+
+// ReactionUsers returns the IDs of the users who reacted to the message with
+// code.
+func (m *Message) ReactionUsers(code string) []string {
+	var users []string
+	for _, r := range m.Reactions {
+		if r.Code == code {
+			users = append(users, r.UserIDs...)
+		}
+	}
+	return users
+}
+
+// Platform is the client a message was sent from.
+type Platform string
+
+// Known platforms.
+const (
+	PlatformGroupMe      Platform = "gm"
+	PlatformSMS          Platform = "sms"
+	PlatformIOS          Platform = "ios"
+	PlatformAndroid      Platform = "android"
+	PlatformWindowsPhone Platform = "wp"
+)
+
+// FromPlatform returns the Platform the message was sent from.
+func (m *Message) FromPlatform() Platform {
+	return Platform(m.Platform)
+}
+
+// CreatedAtTime returns CreatedAt as a UTC time.Time.
+func (m *Message) CreatedAtTime() time.Time {
+	return time.Unix(int64(m.CreatedAt), 0).UTC()
+}
+
+// IsDeleted returns whether the message has been deleted, as opposed to
+// having been sent without text.
+func (m *Message) IsDeleted() bool {
+	return m.DeletedAt != 0 || m.DeletionActor != "" || m.Event.Type == MessageDeletedEventType
+}
+
+// AttachmentCount returns the number of attachments on the message.
+func (m *Message) AttachmentCount() int {
+	return len(m.Attachments)
+}
+
+// FirstImageURL returns the URL of the message's first image attachment.
+func (m *Message) FirstImageURL() (string, bool) {
+	for _, a := range m.Attachments {
+		if a.Type == ImageAttachment {
+			return a.URL, true
+		}
+	}
+	return "", false
 }
 
 // GetMessagesResponse is a the HTTP response from GetMessages (`GET /groups/:group_id/messages`).
@@ -41,9 +147,46 @@ type GetMessagesResponse struct {
 
 // GetMessages retrieves messages for a group.
 func (c *Client) GetMessages(groupID string, limit string, beforeID, sinceID, afterID string) (GetMessagesResponse, error) {
+	return c.GetMessagesWithContext(context.Background(), groupID, limit, beforeID, sinceID, afterID)
+}
+
+// GetMessagesWithContext is GetMessages with a context.
+func (c *Client) GetMessagesWithContext(ctx context.Context, groupID string, limit string, beforeID, sinceID, afterID string) (GetMessagesResponse, error) {
+	messages, err := c.getMessagesAt(ctx, "messages.list", fmt.Sprintf("/groups/%s/messages", groupID), limit, beforeID, sinceID, afterID)
+	if isNotMember(err) {
+		return GetMessagesResponse{}, fmt.Errorf("%w: %w", ErrNotMember, err)
+	}
+	return messages, err
+}
+
+// isNotMember returns whether err is GroupMe's response to reading the
+// messages of a group the user has left or been removed from: 403 Forbidden,
+// or 404 Not Found with a meta error about membership. A bare 404 means the
+// group does not exist.
+func isNotMember(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.code() {
+	case http.StatusForbidden:
+		return true
+	case http.StatusNotFound:
+		for _, msg := range apiErr.Errors {
+			if strings.Contains(strings.ToLower(msg), "member") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getMessagesAt retrieves messages from a messages route: a group's, or one
+// shaped like it.
+func (c *Client) getMessagesAt(ctx context.Context, endpoint, route string, limit string, beforeID, sinceID, afterID string) (GetMessagesResponse, error) {
 	// build query params
 	values := url.Values{}
-	values.Add("token", c.AccessToken)
 	if limit != "" {
 		values.Add("limit", limit)
 	}
@@ -56,69 +199,387 @@ func (c *Client) GetMessages(groupID string, limit string, beforeID, sinceID, af
 	if afterID != "" {
 		values.Add("after_id", afterID)
 	}
-	params := values.Encode()
 
-	// generate URL for request
-	URL, err := createURL(c.BaseURL, fmt.Sprintf("/groups/%s/messages", groupID), params)
+	var messages struct {
+		Count    int               `json:"count"`
+		Messages []json.RawMessage `json:"messages"`
+	}
+	err := c.doRequest(ctx, endpoint, http.MethodGet, route, values, nil, &messages)
 	if err != nil {
 		return GetMessagesResponse{}, err
 	}
 
-	// send request, read body
-	resp, err := http.Get(URL)
-	if err != nil {
-		return GetMessagesResponse{}, err
+	// decode messages, keeping their JSON if asked to
+	resp := GetMessagesResponse{
+		Count:    messages.Count,
+		Messages: make([]*Message, 0, len(messages.Messages)),
 	}
-	body, err := io.ReadAll(resp.Body)
-	resp.Body.Close()
-	if err != nil {
-		return GetMessagesResponse{}, err
+	for _, raw := range messages.Messages {
+		var message Message
+		if err := c.decode(raw, &message); err != nil {
+			return GetMessagesResponse{}, err
+		}
+		if c.RawMessages {
+			message.Raw = raw
+		}
+		resp.Messages = append(resp.Messages, &message)
 	}
 
-	// exit early on error
-	if resp.StatusCode == http.StatusNotModified {
-		return GetMessagesResponse{}, ErrNotModified
-	}
+	return resp, nil
+}
 
-	// parse response
-	var messages struct {
-		Response GetMessagesResponse `json:"response"`
-		Meta     Meta                `json:"meta"`
+// GetMessagesPage retrieves up to limit messages older than beforeID (or the
+// newest messages if beforeID is empty), and whether older messages may
+// remain. A page shorter than limit, or 304 Not Modified, marks the start of
+// history. limit must be positive; GroupMe returns at most 100 messages per
+// page, so larger limits are lowered to 100.
+func (c *Client) GetMessagesPage(ctx context.Context, groupID string, limit int, beforeID string) ([]*Message, bool, error) {
+	if limit <= 0 {
+		return nil, false, fmt.Errorf("groupme: GetMessagesPage: limit must be positive, got %d", limit)
 	}
-	err = json.Unmarshal(body, &messages)
-	if err != nil {
-		return GetMessagesResponse{}, err
+	if limit > maxMessagesPerPage {
+		limit = maxMessagesPerPage
 	}
 
-	// exit early on error
-	if messages.Meta.Code != http.StatusOK {
-		return GetMessagesResponse{}, fmt.Errorf("%d: %s", messages.Meta.Code, fmt.Sprintf("%+v", messages.Meta.Errors))
+	messages, err := c.GetMessagesWithContext(ctx, groupID, strconv.Itoa(limit), beforeID, "", "")
+	if err != nil {
+		if errors.Is(err, ErrNotModified) {
+			return nil, false, nil
+		}
+		return nil, false, err
 	}
 
-	return messages.Response, nil
+	return messages.Messages, len(messages.Messages) == limit, nil
 }
 
 // AllMessages retrieves all messages from a particular group.
 func (c *Client) AllMessages(groupID string) ([]*Message, error) {
+	return c.AllMessagesWithContext(context.Background(), groupID)
+}
+
+// AllMessagesWithContext is AllMessages with a context.
+func (c *Client) AllMessagesWithContext(ctx context.Context, groupID string) ([]*Message, error) {
+	history, _, err := c.AllMessagesFrom(ctx, groupID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// AllMessagesFrom retrieves all messages from a group older than
+// resumeBeforeID, or the whole history if it is empty. If a request fails,
+// the messages retrieved so far are returned along with the before_id cursor
+// to resume from; on success the cursor is empty.
+func (c *Client) AllMessagesFrom(ctx context.Context, groupID, resumeBeforeID string) ([]*Message, string, error) {
 	var history []*Message
 
-	var beforeID string
+	beforeID := resumeBeforeID
 	for {
-		messages, err := c.GetMessages(groupID, "100", beforeID, "", "")
+		messages, err := c.GetMessagesWithContext(ctx, groupID, "100", beforeID, "", "")
+		if err != nil {
+			if errors.Is(err, ErrNotModified) {
+				break
+			}
+			return history, beforeID, err
+		}
+		if len(messages.Messages) == 0 {
+			break
+		}
+		c.observePage("AllMessages")
+		beforeID = messages.Messages[len(messages.Messages)-1].ID
+
+		history = append(history, messages.Messages...)
+	}
+
+	return history, "", nil
+}
+
+// AllMessagesLimited is AllMessages, newest first, stopping once maxMessages
+// messages have been retrieved or messages are older than maxAge. A zero bound
+// is unlimited.
+func (c *Client) AllMessagesLimited(ctx context.Context, groupID string, maxMessages int, maxAge time.Duration) ([]*Message, error) {
+	var history []*Message
+	var cutoff time.Time
+	if maxAge > 0 {
+		cutoff = time.Now().Add(-maxAge)
+	}
+
+	it := c.IterateMessages(groupID)
+	for (maxMessages <= 0 || len(history) < maxMessages) && it.Next(ctx) {
+		message := it.Message()
+		if !cutoff.IsZero() && message.CreatedAtTime().Before(cutoff) {
+			break
+		}
+		history = append(history, message)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// GetMessagesSince retrieves every message in a group created after since, in
+// chronological order. History is scanned backwards to find the newest message
+// at or before since, which is then used as the cursor to page forward. If no
+// such message exists, the entire history is newer than since and is returned.
+func (c *Client) GetMessagesSince(ctx context.Context, groupID string, since time.Time) ([]*Message, error) {
+	var newer []*Message
+	var pivotID, beforeID string
+
+	// scan backwards for the newest message at or before since
+scan:
+	for {
+		messages, err := c.GetMessagesWithContext(ctx, groupID, "100", beforeID, "", "")
 		if err != nil {
 			if errors.Is(err, ErrNotModified) {
 				break
 			}
 			return nil, err
 		}
+		if len(messages.Messages) == 0 {
+			break
+		}
+
+		for _, message := range messages.Messages {
+			if !message.CreatedAtTime().After(since) {
+				pivotID = message.ID
+				break scan
+			}
+			newer = append(newer, message)
+		}
 		beforeID = messages.Messages[len(messages.Messages)-1].ID
+	}
+
+	// nothing at or before since, so everything scanned is newer
+	if pivotID == "" {
+		reverseMessages(newer)
+		return newer, nil
+	}
+
+	// page forwards from the pivot
+	var history []*Message
+	afterID := pivotID
+	for {
+		messages, err := c.GetMessagesWithContext(ctx, groupID, "100", "", "", afterID)
+		if err != nil {
+			if errors.Is(err, ErrNotModified) {
+				break
+			}
+			return nil, err
+		}
+		if len(messages.Messages) == 0 {
+			break
+		}
 
 		history = append(history, messages.Messages...)
+		afterID = messages.Messages[len(messages.Messages)-1].ID
 	}
 
 	return history, nil
 }
 
+// LatestMessage retrieves the newest message in a group, or ErrNoMessages if
+// the group is empty.
+func (c *Client) LatestMessage(ctx context.Context, groupID string) (*Message, error) {
+	messages, err := c.GetMessagesWithContext(ctx, groupID, "1", "", "", "")
+	if err != nil {
+		if errors.Is(err, ErrNotModified) {
+			return nil, ErrNoMessages
+		}
+		return nil, err
+	}
+	if len(messages.Messages) == 0 {
+		return nil, ErrNoMessages
+	}
+
+	return messages.Messages[0], nil
+}
+
+// FirstMessage retrieves the oldest message in a group, or ErrNoMessages if
+// the group has none. GroupMe only pages backwards from the newest message,
+// so this walks the whole history, one request per 100 messages; it stops
+// early with ctx's error if ctx is done.
+func (c *Client) FirstMessage(ctx context.Context, groupID string) (*Message, error) {
+	var first *Message
+
+	it := c.IterateMessages(groupID)
+	for it.Next(ctx) {
+		first = it.Message()
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	if first == nil {
+		return nil, ErrNoMessages
+	}
+
+	return first, nil
+}
+
+// maxMessagesPerPage is the most messages GroupMe returns per request.
+const maxMessagesPerPage = 100
+
+// RecentMessages retrieves the newest n messages of a group, newest first,
+// using as few requests as possible. Fewer are returned if the group has
+// fewer messages.
+func (c *Client) RecentMessages(ctx context.Context, groupID string, n int) ([]*Message, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("groupme: RecentMessages: n must be positive, got %d", n)
+	}
+
+	var all []*Message
+	beforeID := ""
+	for len(all) < n {
+		limit := n - len(all)
+		if limit > maxMessagesPerPage {
+			limit = maxMessagesPerPage
+		}
+		page, more, err := c.GetMessagesPage(ctx, groupID, limit, beforeID)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if !more || len(page) == 0 {
+			break
+		}
+		beforeID = page[len(page)-1].ID
+	}
+
+	return all, nil
+}
+
+// GetMessage retrieves a single message from a group.
+func (c *Client) GetMessage(groupID, messageID string) (*Message, error) {
+	return c.GetMessageWithContext(context.Background(), groupID, messageID)
+}
+
+// GetMessageWithContext is GetMessage with a context.
+func (c *Client) GetMessageWithContext(ctx context.Context, groupID, messageID string) (*Message, error) {
+	var message struct {
+		Message *Message `json:"message"`
+	}
+	err := c.doRequest(ctx, "messages.get", http.MethodGet, fmt.Sprintf("/groups/%s/messages/%s", groupID, messageID), nil, nil, &message)
+	if err != nil {
+		return nil, err
+	}
+	if message.Message == nil {
+		return nil, ErrNotFound
+	}
+
+	return message.Message, nil
+}
+
+// ResolveReply retrieves the message that msg replies to. It returns
+// ErrReplyNotFound if that message has been deleted or cannot be read, and an
+// error if msg is not a reply.
+func (c *Client) ResolveReply(ctx context.Context, groupID string, msg *Message) (*Message, error) {
+	replyID := ""
+	for _, a := range msg.Attachments {
+		if a.Type == ReplyAttachment {
+			replyID = a.ReplyID
+			break
+		}
+	}
+	if replyID == "" {
+		return nil, fmt.Errorf("groupme: message %s is not a reply", msg.ID)
+	}
+
+	target, err := c.GetMessageWithContext(ctx, groupID, replyID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrForbidden) {
+			return nil, fmt.Errorf("%w: %w", ErrReplyNotFound, err)
+		}
+		return nil, err
+	}
+	if target.IsDeleted() {
+		return nil, ErrReplyNotFound
+	}
+
+	return target, nil
+}
+
+// GetMessageContext retrieves a message along with up to before messages
+// preceding it and up to after messages following it, in chronological
+// order. Fewer are returned near the start or end of history.
+func (c *Client) GetMessageContext(ctx context.Context, groupID, messageID string, before, after int) ([]*Message, error) {
+	target, err := c.GetMessageWithContext(ctx, groupID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// older messages come newest first
+	var older []*Message
+	for beforeID := messageID; len(older) < before; {
+		messages, err := c.GetMessagesWithContext(ctx, groupID, strconv.Itoa(min(before-len(older), 100)), beforeID, "", "")
+		if err != nil {
+			if errors.Is(err, ErrNotModified) {
+				break
+			}
+			return nil, err
+		}
+		if len(messages.Messages) == 0 {
+			break
+		}
+
+		older = append(older, messages.Messages...)
+		beforeID = messages.Messages[len(messages.Messages)-1].ID
+	}
+	reverseMessages(older)
+
+	// newer messages come oldest first
+	var newer []*Message
+	for afterID := messageID; len(newer) < after; {
+		messages, err := c.GetMessagesWithContext(ctx, groupID, strconv.Itoa(min(after-len(newer), 100)), "", "", afterID)
+		if err != nil {
+			if errors.Is(err, ErrNotModified) {
+				break
+			}
+			return nil, err
+		}
+		if len(messages.Messages) == 0 {
+			break
+		}
+
+		newer = append(newer, messages.Messages...)
+		afterID = messages.Messages[len(messages.Messages)-1].ID
+	}
+
+	window := make([]*Message, 0, len(older)+1+len(newer))
+	window = append(window, older...)
+	window = append(window, target)
+	window = append(window, newer...)
+
+	return window, nil
+}
+
+// reverseMessages reverses messages in place.
+func reverseMessages(messages []*Message) {
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+}
+
+// GetMessageCount returns the total number of messages in a group, as reported
+// by GroupMe alongside a single page of messages.
+func (c *Client) GetMessageCount(groupID string) (int, error) {
+	return c.GetMessageCountWithContext(context.Background(), groupID)
+}
+
+// GetMessageCountWithContext is GetMessageCount with a context.
+func (c *Client) GetMessageCountWithContext(ctx context.Context, groupID string) (int, error) {
+	messages, err := c.GetMessagesWithContext(ctx, groupID, "1", "", "", "")
+	if err != nil {
+		if errors.Is(err, ErrNotModified) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return messages.Count, nil
+}
+
 // CreateMessageResponse is a the HTTP response from CreateMessages (`POST /groups/:group_id/messages`).
 type CreateMessageResponse struct {
 	Message *Message `json:"message"`
@@ -126,57 +587,113 @@ type CreateMessageResponse struct {
 
 type CreateMessagePayload struct {
 	Message struct {
-		SourceGUID string `json:"source_guid"`
-		Text       string `json:"text"`
-		// TODO: FIXME: XXX: support attachments
+		SourceGUID  string       `json:"source_guid"`
+		Text        string       `json:"text"`
+		Attachments []Attachment `json:"attachments,omitempty"`
 	} `json:"message"`
 }
 
-// CreateNessage creates a message for a group.
+// CreateNessage creates a message for a group. text must not be empty.
 func (c *Client) CreateMessage(groupID string, source_guid string, text string) (CreateMessageResponse, error) {
-	// build query params
-	values := url.Values{}
-	values.Add("token", c.AccessToken)
-	params := values.Encode()
+	return c.CreateMessageWithContext(context.Background(), groupID, source_guid, text)
+}
 
-	// generate URL for request
-	URL, err := createURL(c.BaseURL, fmt.Sprintf("/groups/%s/messages", groupID), params)
-	if err != nil {
+// CreateMessageWithContext is CreateMessage with a context.
+func (c *Client) CreateMessageWithContext(ctx context.Context, groupID string, source_guid string, text string) (CreateMessageResponse, error) {
+	return c.createMessage(ctx, groupID, source_guid, text, nil)
+}
+
+func (c *Client) createMessage(ctx context.Context, groupID string, source_guid string, text string, attachments []Attachment) (CreateMessageResponse, error) {
+	if err := validateOutgoing(OutgoingMessage{Text: text, Attachments: attachments}); err != nil {
 		return CreateMessageResponse{}, err
 	}
 
 	msg := CreateMessagePayload{}
 	msg.Message.SourceGUID = source_guid
 	msg.Message.Text = text
+	msg.Message.Attachments = attachments
 
-	buf, err := json.Marshal(msg)
+	// GroupMe responds 201 Created, or an error status with meta errors
+	var message CreateMessageResponse
+	err := c.doRequest(ctx, "messages.create", http.MethodPost, fmt.Sprintf("/groups/%s/messages", groupID), nil, msg, &message)
 	if err != nil {
 		return CreateMessageResponse{}, err
 	}
 
-	// send request, read body
-	resp, err := http.Post(URL, "application/json", bytes.NewBuffer(buf))
-	if err != nil {
-		return CreateMessageResponse{}, err
-	}
-	body, err := io.ReadAll(resp.Body)
-	resp.Body.Close()
-	if err != nil {
-		return CreateMessageResponse{}, err
-	}
+	return message, nil
+}
+
+// DeleteMessage deletes a message from a group. groupID may also be the
+// conversation ID of a direct message conversation (see ConversationID).
+func (c *Client) DeleteMessage(groupID, messageID string) error {
+	return c.DeleteMessageWithContext(context.Background(), groupID, messageID)
+}
+
+// DeleteMessageWithContext is DeleteMessage with a context.
+func (c *Client) DeleteMessageWithContext(ctx context.Context, groupID, messageID string) error {
+	return c.doRequest(ctx, "messages.delete", http.MethodDelete, fmt.Sprintf("/conversations/%s/messages/%s", groupID, messageID), nil, nil, nil)
+}
+
+// PinMessage pins a message in a group. GroupMe does not document its pinning
+// endpoints, so they may change without notice.
+func (c *Client) PinMessage(groupID, messageID string) error {
+	return c.PinMessageWithContext(context.Background(), groupID, messageID)
+}
+
+// PinMessageWithContext is PinMessage with a context.
+func (c *Client) PinMessageWithContext(ctx context.Context, groupID, messageID string) error {
+	return c.doRequest(ctx, "messages.pin", http.MethodPost, fmt.Sprintf("/conversations/%s/messages/%s/pin", groupID, messageID), nil, nil, nil)
+}
+
+// UnpinMessage unpins a message in a group.
+func (c *Client) UnpinMessage(groupID, messageID string) error {
+	return c.UnpinMessageWithContext(context.Background(), groupID, messageID)
+}
+
+// UnpinMessageWithContext is UnpinMessage with a context.
+func (c *Client) UnpinMessageWithContext(ctx context.Context, groupID, messageID string) error {
+	return c.doRequest(ctx, "messages.unpin", http.MethodPost, fmt.Sprintf("/conversations/%s/messages/%s/unpin", groupID, messageID), nil, nil, nil)
+}
+
+// deleteConcurrency is the number of concurrent requests made by DeleteMessages.
+const deleteConcurrency = 4
+
+// DeleteMessages deletes many messages from a group concurrently. It returns
+// the IDs that were deleted, in the order given, and the error for each ID
+// that could not be. Messages not yet attempted when ctx is done fail with
+// the context's error.
+func (c *Client) DeleteMessages(ctx context.Context, groupID string, messageIDs []string) (deleted []string, failed map[string]error) {
+	errs := make([]error, len(messageIDs))
 
-	// exit early on error
-	if resp.StatusCode == http.StatusNotModified {
-		return CreateMessageResponse{}, ErrNotModified
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, deleteConcurrency)
+	for i, id := range messageIDs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = c.DeleteMessageWithContext(ctx, groupID, id)
+		}(i, id)
 	}
+	wg.Wait()
 
-	var message CreateMessageResponse
-	err = json.Unmarshal(body, &message)
-	if err != nil {
-		return CreateMessageResponse{}, err
+	failed = map[string]error{}
+	for i, id := range messageIDs {
+		if errs[i] != nil {
+			failed[id] = errs[i]
+			continue
+		}
+		deleted = append(deleted, id)
 	}
 
-	return message, nil
+	return deleted, failed
 }
 
 //POST /groups/:group_id/messages
@@ -0,0 +1,815 @@
+package groupme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFromPlatform(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Platform
+	}{
+		{"gm", PlatformGroupMe},
+		{"sms", PlatformSMS},
+		{"ios", PlatformIOS},
+		{"android", PlatformAndroid},
+		{"wp", PlatformWindowsPhone},
+		{"smartwatch", Platform("smartwatch")},
+	}
+
+	for _, tt := range tests {
+		var m Message
+		if err := json.Unmarshal([]byte(`{"platform":"`+tt.raw+`"}`), &m); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		if got := m.FromPlatform(); got != tt.want {
+			t.Errorf("FromPlatform() for %q = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+// fakeHistory serves a group's messages the way GroupMe pages them: before_id
+// and since_id pages newest first, after_id pages oldest first, and 304 Not
+// Modified once a cursor reaches the end of history. Requests for a single
+// message, by a path ending in its ID, get that message or 404 Not Found.
+type fakeHistory struct {
+	mu       sync.Mutex
+	messages []*Message // oldest first
+	requests []url.Values
+
+	// failOn, if non-zero, fails the request with that 1-based number.
+	failOn int
+}
+
+// newFakeHistory returns a fakeHistory of n messages with IDs "1" to "n",
+// created a minute apart starting at start.
+func newFakeHistory(n int, start time.Time) *fakeHistory {
+	h := &fakeHistory{}
+	for i := 1; i <= n; i++ {
+		h.messages = append(h.messages, &Message{
+			ID:        strconv.Itoa(i),
+			GroupID:   "g1",
+			UserID:    "u" + strconv.Itoa(i%3),
+			Text:      "message " + strconv.Itoa(i),
+			CreatedAt: int(start.Add(time.Duration(i-1) * time.Minute).Unix()),
+		})
+	}
+	return h
+}
+
+func (h *fakeHistory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	q := r.URL.Query()
+	h.requests = append(h.requests, q)
+	if h.failOn == len(h.requests) {
+		writeMetaError(w, http.StatusInternalServerError, "failed")
+		return
+	}
+
+	limit := 20
+	if l := q.Get("limit"); l != "" {
+		limit, _ = strconv.Atoi(l)
+	}
+	if limit > maxMessagesPerPage {
+		limit = maxMessagesPerPage
+	}
+
+	// index of the message with the given ID
+	index := func(id string) int {
+		for i, m := range h.messages {
+			if m.ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	if id, ok := strings.CutPrefix(r.URL.Path, "/groups/g1/messages/"); ok {
+		i := index(id)
+		if i < 0 {
+			writeMetaError(w, http.StatusNotFound, "message not found")
+			return
+		}
+		writeEnvelope(w, http.StatusOK, map[string]*Message{"message": h.messages[i]})
+		return
+	}
+
+	var page []*Message
+	switch {
+	case q.Get("after_id") != "":
+		i := index(q.Get("after_id"))
+		page = h.messages[i+1 : min(i+1+limit, len(h.messages))]
+	case q.Get("since_id") != "":
+		i := index(q.Get("since_id"))
+		for j := len(h.messages) - 1; j > i && len(page) < limit; j-- {
+			page = append(page, h.messages[j])
+		}
+	default:
+		end := len(h.messages)
+		if id := q.Get("before_id"); id != "" {
+			end = index(id)
+		}
+		for j := end - 1; j >= 0 && len(page) < limit; j-- {
+			page = append(page, h.messages[j])
+		}
+	}
+
+	if len(page) == 0 {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeEnvelope(w, http.StatusOK, GetMessagesResponse{Count: len(h.messages), Messages: page})
+}
+
+// add appends a message, with the next ID, to the history.
+func (h *fakeHistory) add(text string, createdAt time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.messages = append(h.messages, &Message{
+		ID:        strconv.Itoa(len(h.messages) + 1),
+		GroupID:   "g1",
+		Text:      text,
+		CreatedAt: int(createdAt.Unix()),
+	})
+}
+
+// requestCount returns the number of requests served.
+func (h *fakeHistory) requestCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.requests)
+}
+
+// serveFixture returns a handler that responds with a file from testdata.
+func serveFixture(t *testing.T, name string) http.Handler {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+}
+
+func TestGetMessagesFixture(t *testing.T) {
+	c := newTestClient(t, serveFixture(t, "messages.json"))
+
+	resp, err := c.GetMessages("12345678", "", "", "", "")
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	if resp.Count != 1234 {
+		t.Errorf("Count = %d, want 1234", resp.Count)
+	}
+	if len(resp.Messages) != 4 {
+		t.Fatalf("got %d messages, want 4", len(resp.Messages))
+	}
+
+	m := resp.Messages[0]
+	want := &Message{
+		ID:          "160000000000000003",
+		SourceGUID:  "b6a6f3a0-7f3a-4c31-9a53-3a4c2b1b2f10",
+		UserID:      "4444",
+		GroupID:     "12345678",
+		SenderID:    "4444",
+		Name:        "Zoë",
+		AvatarURL:   "https://i.groupme.com/200x200.jpeg.fedcba9876543210",
+		Text:        "Hello @Alex and @Jamie 👋",
+		SenderType:  "user",
+		Platform:    "gm",
+		CreatedAt:   1700000300,
+		FavoritedBy: []string{"1111", "3333"},
+		Attachments: []Attachment{
+			{Type: ImageAttachment, URL: "https://i.groupme.com/1024x768.jpeg.0123456789abcdef"},
+			{Type: MentionsAttachment, UserIDs: []string{"1111", "2222"}, Loci: [][]int{{6, 5}, {16, 6}}},
+			{Type: ReplyAttachment, ReplyID: "160000000000000001", BaseReplyID: "160000000000000001"},
+		},
+		Reactions: []Reaction{{Type: "unicode", Code: "❤️", UserIDs: []string{"1111", "3333"}}},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("message 0 =\n%+v\nwant\n%+v", m, want)
+	}
+
+	m = resp.Messages[1]
+	if m.PinnedAt != 1700000250 || m.AvatarURL != "" || m.FromPlatform() != PlatformAndroid {
+		t.Errorf("message 1: PinnedAt = %d, AvatarURL = %q, Platform = %q", m.PinnedAt, m.AvatarURL, m.Platform)
+	}
+	if len(m.Attachments) != 7 {
+		t.Fatalf("message 1: got %d attachments, want 7", len(m.Attachments))
+	}
+
+	m = resp.Messages[2]
+	if !m.System || m.Event.Type != MemberAddedEventType || !m.Event.Exists() {
+		t.Errorf("message 2: System = %t, Event = %+v", m.System, m.Event)
+	}
+
+	m = resp.Messages[3]
+	if !m.IsDeleted() || m.DeletedAt != 1700000050 || m.DeletionActor != "sender" || m.Text != "" {
+		t.Errorf("message 3: DeletedAt = %d, DeletionActor = %q, Text = %q", m.DeletedAt, m.DeletionActor, m.Text)
+	}
+}
+
+func TestMessageRoundTrip(t *testing.T) {
+	c := newTestClient(t, serveFixture(t, "messages.json"))
+
+	resp, err := c.GetMessages("12345678", "", "", "", "")
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+
+	for _, m := range resp.Messages {
+		buf, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("Marshal message %s: %v", m.ID, err)
+		}
+		var got Message
+		if err := json.Unmarshal(buf, &got); err != nil {
+			t.Fatalf("Unmarshal message %s: %v", m.ID, err)
+		}
+		if !reflect.DeepEqual(&got, m) {
+			t.Errorf("message %s after round trip =\n%+v\nwant\n%+v", m.ID, &got, m)
+		}
+	}
+}
+
+func TestMessageMarshalShape(t *testing.T) {
+	buf, err := json.Marshal(Message{ID: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(fields["favorited_by"]); got != "[]" {
+		t.Errorf("favorited_by = %s, want []", got)
+	}
+	if got := string(fields["attachments"]); got != "[]" {
+		t.Errorf("attachments = %s, want []", got)
+	}
+	for _, key := range []string{"event", "reactions", "deleted_at", "pinned_at", "recipient_id"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("%s present in %s", key, buf)
+		}
+	}
+}
+
+func TestCreateMessagePayloadJSON(t *testing.T) {
+	tests := []struct {
+		fixture     string
+		guid        string
+		text        string
+		attachments []Attachment
+	}{
+		{
+			fixture: "create_message_payload.json",
+			guid:    "b6a6f3a0-7f3a-4c31-9a53-3a4c2b1b2f10",
+			text:    "Hello @Alex 📍",
+			attachments: []Attachment{
+				{Type: MentionsAttachment, UserIDs: []string{"1111"}, Loci: [][]int{{6, 5}}},
+				NewLocationAttachment("Station 1", "41.8486", "-71.8853"),
+				NewImageAttachment("https://i.groupme.com/1024x768.jpeg.0123456789abcdef"),
+				NewReplyAttachment("160000000000000001"),
+			},
+		},
+		{
+			fixture: "create_message_payload_text.json",
+			guid:    "guid-1",
+			text:    "just text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []byte
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = io.ReadAll(r.Body)
+				writeEnvelope(w, http.StatusCreated, CreateMessageResponse{Message: &Message{ID: "1"}})
+			}))
+			if _, err := c.createMessage(context.Background(), "g1", tt.guid, tt.text, tt.attachments); err != nil {
+				t.Fatalf("createMessage: %v", err)
+			}
+
+			if string(got) != string(bytes.TrimSpace(want)) {
+				t.Errorf("payload =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestGetMessagesNotMember(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		errors []string
+		want   bool
+	}{
+		{"forbidden", http.StatusForbidden, nil, true},
+		{"removed", http.StatusNotFound, []string{"You are not a member of this group"}, true},
+		{"no such group", http.StatusNotFound, []string{"not found"}, false},
+		{"server error", http.StatusInternalServerError, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				writeMetaError(w, tt.status, tt.errors...)
+			}))
+
+			_, err := c.GetMessages("g1", "", "", "", "")
+			if got := errors.Is(err, ErrNotMember); got != tt.want {
+				t.Errorf("errors.Is(%v, ErrNotMember) = %t, want %t", err, got, tt.want)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("err = %v, want an APIError with status %d", err, tt.status)
+			}
+		})
+	}
+}
+
+func TestTopicMessagesForbidden(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeMetaError(w, http.StatusForbidden)
+	}))
+
+	_, err := c.GetTopicMessages("g1", "t1", "")
+	if !errors.Is(err, ErrForbidden) || errors.Is(err, ErrNotMember) {
+		t.Errorf("err = %v, want ErrForbidden and not ErrNotMember", err)
+	}
+}
+
+func TestAllMessagesFromResume(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	h.failOn = 3
+	c := newTestClient(t, h)
+
+	history, cursor, err := c.AllMessagesFrom(context.Background(), "g1", "")
+	if !errors.Is(err, ErrInternalServerError) {
+		t.Fatalf("err = %v, want ErrInternalServerError", err)
+	}
+	if len(history) != 200 || cursor != "51" {
+		t.Fatalf("got %d messages and cursor %q, want 200 and \"51\"", len(history), cursor)
+	}
+
+	rest, cursor, err := c.AllMessagesFrom(context.Background(), "g1", cursor)
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if cursor != "" {
+		t.Errorf("cursor after success = %q, want empty", cursor)
+	}
+
+	history = append(history, rest...)
+	if len(history) != 250 {
+		t.Fatalf("got %d messages, want 250", len(history))
+	}
+	for i, m := range history {
+		if want := strconv.Itoa(250 - i); m.ID != want {
+			t.Fatalf("message %d has ID %s, want %s", i, m.ID, want)
+		}
+	}
+}
+
+func TestAllMessages(t *testing.T) {
+	h := newFakeHistory(120, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+
+	history, err := c.AllMessages("g1")
+	if err != nil {
+		t.Fatalf("AllMessages: %v", err)
+	}
+	if len(history) != 120 || history[0].ID != "120" || history[119].ID != "1" {
+		t.Errorf("got %d messages, want 120 newest first", len(history))
+	}
+}
+
+func TestCreateMessageMetaError(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"meta":{"code":400,"errors":["Text is required"]},"response":null}`)
+	}))
+
+	resp, err := c.CreateMessage("g1", "guid", " ")
+	if !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("err = %v, want ErrBadRequest", err)
+	}
+	if resp.Message != nil {
+		t.Errorf("Message = %+v, want nil", resp.Message)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) != 1 || apiErr.Errors[0] != "Text is required" {
+		t.Errorf("err = %#v, want an APIError carrying the meta errors", err)
+	}
+}
+
+func TestCreateMessage(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/groups/g1/messages" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		writeEnvelope(w, http.StatusCreated, CreateMessageResponse{Message: &Message{ID: "m1", Text: "hi"}})
+	}))
+
+	resp, err := c.CreateMessage("g1", "guid", "hi")
+	if err != nil {
+		t.Fatalf("CreateMessage: %v", err)
+	}
+	if resp.Message == nil || resp.Message.ID != "m1" {
+		t.Errorf("Message = %+v, want m1", resp.Message)
+	}
+}
+
+func TestAttachmentAccessorsOnMessage(t *testing.T) {
+	m := &Message{Attachments: []Attachment{
+		NewLocationAttachment("Station 1", "1", "2"),
+		NewImageAttachment("https://i.groupme.com/first"),
+		NewImageAttachment("https://i.groupme.com/second"),
+	}}
+	if got := m.AttachmentCount(); got != 3 {
+		t.Errorf("AttachmentCount = %d, want 3", got)
+	}
+	if url, ok := m.FirstImageURL(); !ok || url != "https://i.groupme.com/first" {
+		t.Errorf("FirstImageURL = %q, %t", url, ok)
+	}
+
+	empty := &Message{}
+	if got := empty.AttachmentCount(); got != 0 {
+		t.Errorf("AttachmentCount of no attachments = %d", got)
+	}
+	if _, ok := empty.FirstImageURL(); ok {
+		t.Error("FirstImageURL of no attachments: ok = true")
+	}
+}
+
+func TestGetMessagesPage(t *testing.T) {
+	h := newFakeHistory(150, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+	ctx := context.Background()
+
+	// limits above GroupMe's maximum are lowered to it
+	page, more, err := c.GetMessagesPage(ctx, "g1", 500, "")
+	if err != nil {
+		t.Fatalf("GetMessagesPage: %v", err)
+	}
+	if len(page) != 100 || !more {
+		t.Errorf("got %d messages, more = %t; want 100, true", len(page), more)
+	}
+	if got := h.requests[0].Get("limit"); got != "100" {
+		t.Errorf("limit sent = %s, want 100", got)
+	}
+
+	page, more, err = c.GetMessagesPage(ctx, "g1", 100, page[len(page)-1].ID)
+	if err != nil {
+		t.Fatalf("GetMessagesPage: %v", err)
+	}
+	if len(page) != 50 || more {
+		t.Errorf("got %d messages, more = %t; want 50, false", len(page), more)
+	}
+
+	page, more, err = c.GetMessagesPage(ctx, "g1", 100, "1")
+	if err != nil || len(page) != 0 || more {
+		t.Errorf("past the start: got %d messages, more = %t, err = %v", len(page), more, err)
+	}
+
+	for _, limit := range []int{0, -1} {
+		if _, _, err := c.GetMessagesPage(ctx, "g1", limit, ""); err == nil {
+			t.Errorf("GetMessagesPage with limit %d: want error", limit)
+		}
+	}
+	if got := h.requestCount(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+}
+
+func TestRecentMessages(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+
+	messages, err := c.RecentMessages(context.Background(), "g1", 130)
+	if err != nil {
+		t.Fatalf("RecentMessages: %v", err)
+	}
+	if len(messages) != 130 || messages[0].ID != "250" || messages[129].ID != "121" {
+		t.Errorf("got %d messages", len(messages))
+	}
+	if got := h.requests[1].Get("limit"); got != "30" {
+		t.Errorf("second limit = %s, want 30", got)
+	}
+}
+
+func TestIsDeleted(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want bool
+	}{
+		{"deleted_at", `{"id":"1","text":null,"deleted_at":1700000050}`, true},
+		{"deletion_actor", `{"id":"1","text":null,"deletion_actor":"admin"}`, true},
+		{"deleted event", `{"id":"1","event":{"type":"message.deleted","data":{}}}`, true},
+		{"attachment only", `{"id":"1","text":null,"attachments":[{"type":"image","url":"u"}]}`, false},
+		{"empty text", `{"id":"1","text":""}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Message
+			if err := json.Unmarshal([]byte(tt.json), &m); err != nil {
+				t.Fatal(err)
+			}
+			if got := m.IsDeleted(); got != tt.want {
+				t.Errorf("IsDeleted() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateMessageNotModified(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+
+	// a 304 means nothing for a POST, so it is an unexpected status
+	resp, err := c.CreateMessage("g1", "guid", "hi")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotModified {
+		t.Fatalf("err = %v, want an *APIError with status 304", err)
+	}
+	if errors.Is(err, ErrNotModified) {
+		t.Errorf("err = %v, want it not to be ErrNotModified", err)
+	}
+	if resp.Message != nil {
+		t.Errorf("Message = %+v, want nil", resp.Message)
+	}
+}
+
+func TestReactions(t *testing.T) {
+	const raw = `{"id":"1","reactions":[{"type":"unicode","code":"❤️","user_ids":["1","2"]},{"type":"unicode","code":"😂","user_ids":["3"]},{"type":"emoji","code":"❤️","user_ids":["4"]}]}`
+
+	var m Message
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.ReactionCount("❤️"); got != 3 {
+		t.Errorf("ReactionCount(❤️) = %d, want 3", got)
+	}
+	if got, want := m.ReactionUsers("😂"), []string{"3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReactionUsers(😂) = %v, want %v", got, want)
+	}
+	if got := m.ReactionCount("👍"); got != 0 {
+		t.Errorf("ReactionCount(👍) = %d, want 0", got)
+	}
+
+	var none Message
+	json.Unmarshal([]byte(`{"id":"2"}`), &none)
+	if none.Reactions != nil || none.ReactionCount("❤️") != 0 {
+		t.Errorf("message without reactions: %+v", none.Reactions)
+	}
+}
+
+func TestAllMessagesLimited(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	t.Run("count", func(t *testing.T) {
+		h := newFakeHistory(300, now.Add(-300*time.Minute))
+		c := newTestClient(t, h)
+
+		messages, err := c.AllMessagesLimited(ctx, "g1", 150, 0)
+		if err != nil {
+			t.Fatalf("AllMessagesLimited: %v", err)
+		}
+		if len(messages) != 150 || messages[0].ID != "300" || messages[149].ID != "151" {
+			t.Errorf("got %d messages", len(messages))
+		}
+		if got := h.requestCount(); got != 2 {
+			t.Errorf("requests = %d, want 2", got)
+		}
+	})
+
+	t.Run("age", func(t *testing.T) {
+		// one message a minute, the newest a minute old
+		h := newFakeHistory(300, now.Add(-300*time.Minute))
+		c := newTestClient(t, h)
+
+		messages, err := c.AllMessagesLimited(ctx, "g1", 0, 90*time.Minute+30*time.Second)
+		if err != nil {
+			t.Fatalf("AllMessagesLimited: %v", err)
+		}
+		if len(messages) != 90 || messages[89].ID != "211" {
+			t.Errorf("got %d messages", len(messages))
+		}
+		if got := h.requestCount(); got != 1 {
+			t.Errorf("requests = %d, want 1", got)
+		}
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		h := newFakeHistory(150, now.Add(-150*time.Minute))
+		c := newTestClient(t, h)
+
+		messages, err := c.AllMessagesLimited(ctx, "g1", 0, 0)
+		if err != nil || len(messages) != 150 {
+			t.Errorf("got %d messages, err = %v", len(messages), err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		h := newFakeHistory(300, now.Add(-300*time.Minute))
+		h.failOn = 2
+		c := newTestClient(t, h)
+
+		if _, err := c.AllMessagesLimited(ctx, "g1", 250, 0); !errors.Is(err, ErrInternalServerError) {
+			t.Errorf("err = %v, want ErrInternalServerError", err)
+		}
+	})
+}
+
+func TestFirstMessage(t *testing.T) {
+	tests := []struct {
+		messages     int
+		wantRequests int
+	}{
+		// a short page ends the history without another request
+		{250, 3},
+		{50, 1},
+		// a full last page needs one more request to find the end
+		{200, 3},
+	}
+
+	for _, tt := range tests {
+		h := newFakeHistory(tt.messages, time.Unix(1700000000, 0))
+		c := newTestClient(t, h)
+
+		first, err := c.FirstMessage(context.Background(), "g1")
+		if err != nil {
+			t.Fatalf("%d messages: FirstMessage: %v", tt.messages, err)
+		}
+		if first.ID != "1" {
+			t.Errorf("%d messages: first = %s, want 1", tt.messages, first.ID)
+		}
+		if got := h.requestCount(); got != tt.wantRequests {
+			t.Errorf("%d messages: requests = %d, want %d", tt.messages, got, tt.wantRequests)
+		}
+	}
+}
+
+func TestFirstMessageEmpty(t *testing.T) {
+	c := newTestClient(t, newFakeHistory(0, time.Now()))
+
+	if _, err := c.FirstMessage(context.Background(), "g1"); !errors.Is(err, ErrNoMessages) {
+		t.Errorf("err = %v, want ErrNoMessages", err)
+	}
+}
+
+func TestFirstMessageCanceled(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.FirstMessage(ctx, "g1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if got := h.requestCount(); got != 0 {
+		t.Errorf("requests = %d, want 0", got)
+	}
+}
+
+func TestGetMessagesSince(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		since    time.Time
+		first    int // ID of the oldest message returned, 0 for none
+		wantGets int
+	}{
+		// the pivot is on the first page back, and one page forward follows
+		{"pivot on first page", start.Add(219 * time.Minute), 221, 3},
+		// the pivot is two pages back; paging forward takes three pages
+		{"pivot deep", start.Add(29 * time.Minute), 31, 7},
+		// a since between two messages pivots on the older one
+		{"between messages", start.Add(219*time.Minute + 30*time.Second), 221, 3},
+		// nothing predates since: the whole history, scanned once
+		{"nothing older", start.Add(-time.Minute), 1, 4},
+		{"nothing newer", start.Add(time.Hour * 24), 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newFakeHistory(250, start)
+			c := newTestClient(t, h)
+
+			messages, err := c.GetMessagesSince(context.Background(), "g1", tt.since)
+			if err != nil {
+				t.Fatalf("GetMessagesSince: %v", err)
+			}
+
+			var want []string
+			if tt.first > 0 {
+				for i := tt.first; i <= 250; i++ {
+					want = append(want, strconv.Itoa(i))
+				}
+			}
+			if got := messageIDs(messages); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %d messages %v, want %d from %d on, oldest first", len(got), got, len(want), tt.first)
+			}
+			if got := h.requestCount(); got != tt.wantGets {
+				t.Errorf("requests = %d, want %d", got, tt.wantGets)
+			}
+		})
+	}
+}
+
+func TestGetMessagesSinceError(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	// fail paging forward, after the pivot is found
+	h.failOn = 3
+	c := newTestClient(t, h)
+
+	messages, err := c.GetMessagesSince(context.Background(), "g1", time.Unix(1700000000, 0).Add(29*time.Minute))
+	if !errors.Is(err, ErrInternalServerError) || messages != nil {
+		t.Fatalf("GetMessagesSince = %d messages, %v; want ErrInternalServerError", len(messages), err)
+	}
+}
+
+func TestGetMessageContext(t *testing.T) {
+	ids := func(from, to int) []string {
+		var ids []string
+		for i := from; i <= to; i++ {
+			ids = append(ids, strconv.Itoa(i))
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name          string
+		id            string
+		before, after int
+		want          []string
+	}{
+		{"middle", "100", 3, 2, ids(97, 102)},
+		{"oldest", "1", 5, 2, ids(1, 3)},
+		{"near start", "3", 5, 1, ids(1, 4)},
+		{"newest", "250", 2, 5, ids(248, 250)},
+		{"near end", "249", 1, 5, ids(248, 250)},
+		{"more than a page", "200", 150, 0, ids(50, 200)},
+		{"alone", "50", 0, 0, ids(50, 50)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newFakeHistory(250, time.Unix(1700000000, 0))
+			c := newTestClient(t, h)
+
+			window, err := c.GetMessageContext(context.Background(), "g1", tt.id, tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("GetMessageContext: %v", err)
+			}
+			if got := messageIDs(window); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("window = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMessageContextMissing(t *testing.T) {
+	h := newFakeHistory(10, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+
+	window, err := c.GetMessageContext(context.Background(), "g1", "404", 2, 2)
+	if !errors.Is(err, ErrNotFound) || window != nil {
+		t.Fatalf("GetMessageContext = %v, %v; want ErrNotFound", window, err)
+	}
+	// nothing is paged around a message that does not exist
+	if got := h.requestCount(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
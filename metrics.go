@@ -0,0 +1,105 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Metrics observes the requests a Client makes, e.g. to feed Prometheus
+// counters and histograms.
+type Metrics interface {
+	// ObserveRequest is called once per request with a stable endpoint
+	// name such as "messages.list", the class of the response's status
+	// ("2xx", "4xx", ..., or "error" if no response was received), how long
+	// the request took, and the error it returned, if any.
+	ObserveRequest(endpoint, statusClass string, duration time.Duration, err error)
+}
+
+// RateLimitObserver is implemented by Metrics that also count rate limited
+// requests. ObserveRateLimit is called each time a request is rejected as
+// rate limited, with how long the Client will wait before retrying it.
+type RateLimitObserver interface {
+	ObserveRateLimit(endpoint string, wait time.Duration)
+}
+
+// PageObserver is implemented by Metrics that also count the pages fetched by
+// operations that walk a whole history, such as AllMessages. ObservePage is
+// called once per page.
+type PageObserver interface {
+	ObservePage(operation string)
+}
+
+// observePage reports a page fetched by operation to the Client's Metrics, if
+// they count pages.
+func (c *Client) observePage(operation string) {
+	if o, ok := c.Metrics.(PageObserver); ok {
+		o.ObservePage(operation)
+	}
+}
+
+// WithLogger sets the Client's Logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.Logger = logger
+	}
+}
+
+// observe reports a request to the Client's Metrics and Logger, if any.
+func (c *Client) observe(endpoint, method string, status int, duration time.Duration, err error) {
+	if c.Metrics != nil {
+		c.Metrics.ObserveRequest(endpoint, statusClass(status), duration, err)
+	}
+	if c.Logger != nil {
+		c.logRequest(endpoint, method, status, duration, err)
+	}
+}
+
+// logRequest logs a request at debug level.
+func (c *Client) logRequest(endpoint, method string, status int, duration time.Duration, err error) {
+	attrs := []slog.Attr{
+		slog.String("endpoint", endpoint),
+		slog.String("method", method),
+		slog.Int("status", status),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redactError(err)))
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && len(apiErr.Errors) > 0 {
+			attrs = append(attrs, slog.Any("meta_errors", apiErr.Errors))
+		}
+	}
+
+	c.Logger.LogAttrs(context.Background(), slog.LevelDebug, "groupme request", attrs...)
+}
+
+// redactError returns err's message with any access token in a request URL
+// removed.
+func redactError(err error) string {
+	msg := err.Error()
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if u, parseErr := url.Parse(urlErr.URL); parseErr == nil && u.Query().Has("token") {
+			q := u.Query()
+			q.Set("token", "REDACTED")
+			u.RawQuery = q.Encode()
+			msg = strings.ReplaceAll(msg, urlErr.URL, u.String())
+		}
+	}
+
+	return msg
+}
+
+// statusClass returns the class of an HTTP status code, e.g. "2xx".
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "error"
+	}
+	return fmt.Sprintf("%dxx", status/100)
+}
@@ -0,0 +1,172 @@
+package groupme
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"unicode/utf8"
+)
+
+// MaxMessageLength is the maximum number of characters in a message's text.
+const MaxMessageLength = 1000
+
+// OutgoingMessage is a message to be sent with CreateRichMessage.
+//
+// GroupMe accepts any number of image attachments alongside at most one
+// location, one mentions and one reply attachment; see CombineAttachments.
+type OutgoingMessage struct {
+	// Text may be empty if there is at least one attachment.
+	Text string
+
+	// SourceGUID deduplicates retried sends. One is generated if empty.
+	SourceGUID string
+
+	Attachments []Attachment
+}
+
+// CreateRichMessage validates and creates a message, with attachments, for a
+// group. Images attached with ImageFile are uploaded concurrently first, and
+// nothing is sent if any upload fails.
+func (c *Client) CreateRichMessage(ctx context.Context, groupID string, msg OutgoingMessage) (*Message, error) {
+	attachments, err := CombineAttachments(msg.Attachments...)
+	if err != nil {
+		return nil, err
+	}
+	msg.Attachments = attachments
+	if err := validateOutgoing(msg); err != nil {
+		return nil, err
+	}
+
+	payload := CreateMessagePayload{}
+	payload.Message.SourceGUID = msg.SourceGUID
+	if payload.Message.SourceGUID == "" {
+		payload.Message.SourceGUID = NewSourceGUID()
+	}
+	payload.Message.Text = msg.Text
+	payload.Message.Attachments, err = c.uploadImages(ctx, msg.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CreateMessageResponse
+	err = c.doRequest(ctx, "messages.create", http.MethodPost, fmt.Sprintf("/groups/%s/messages", groupID), nil, payload, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Message, nil
+}
+
+// CombineAttachments checks that attachments can be sent together in one
+// message, merging multiple mentions attachments into one at the position of
+// the first. A message may carry at most one location and one reply
+// attachment, and a poll cannot be combined with any other attachment;
+// breaking these rules returns ErrInvalidMessage, since GroupMe would
+// otherwise silently drop some of them.
+func CombineAttachments(attachments ...Attachment) ([]Attachment, error) {
+	var combined []Attachment
+	mentions := -1
+	counts := map[string]int{}
+	for _, a := range attachments {
+		counts[a.Type]++
+		if a.Type == MentionsAttachment {
+			if mentions >= 0 {
+				m := &combined[mentions]
+				m.UserIDs = append(append([]string(nil), m.UserIDs...), a.UserIDs...)
+				m.Loci = append(append([][]int(nil), m.Loci...), a.Loci...)
+				continue
+			}
+			mentions = len(combined)
+		}
+		combined = append(combined, a)
+	}
+
+	for _, t := range []string{LocationAttachment, ReplyAttachment, PollAttachment} {
+		if counts[t] > 1 {
+			return nil, fmt.Errorf("%w: more than one %s attachment", ErrInvalidMessage, t)
+		}
+	}
+	if counts[PollAttachment] > 0 && len(combined) > 1 {
+		return nil, fmt.Errorf("%w: a poll cannot be combined with other attachments", ErrInvalidMessage)
+	}
+
+	return combined, nil
+}
+
+// ForwardMessage reposts a message to another group under a fresh source GUID,
+// copying its text and its image and location attachments. Other attachments,
+// such as mentions and replies, refer to the original group and are dropped.
+func (c *Client) ForwardMessage(ctx context.Context, msg *Message, toGroupID string) (*Message, error) {
+	out := OutgoingMessage{Text: msg.Text}
+	for _, a := range msg.Attachments {
+		if a.Type == ImageAttachment || a.Type == LocationAttachment {
+			out.Attachments = append(out.Attachments, a)
+		}
+	}
+
+	return c.CreateRichMessage(ctx, toGroupID, out)
+}
+
+// uploadImages uploads the images of any ImageFile attachments concurrently,
+// returning attachments with their URLs filled in. It fails if any upload does.
+func (c *Client) uploadImages(ctx context.Context, attachments []Attachment) ([]Attachment, error) {
+	uploaded := make([]Attachment, len(attachments))
+	copy(uploaded, attachments)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(uploaded))
+	for i := range uploaded {
+		if uploaded[i].upload == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(a *Attachment, err *error) {
+			defer wg.Done()
+			a.URL, *err = c.UploadImage(ctx, a.upload.r, a.upload.contentType)
+			if *err != nil {
+				cancel()
+			}
+			a.upload = nil
+		}(&uploaded[i], &errs[i])
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return uploaded, nil
+}
+
+// validateOutgoing checks msg's text against GroupMe's message constraints.
+// Attachments are checked by CombineAttachments.
+func validateOutgoing(msg OutgoingMessage) error {
+	if msg.Text == "" && len(msg.Attachments) == 0 {
+		return fmt.Errorf("%w: a message needs text or an attachment", ErrInvalidMessage)
+	}
+	if n := utf8.RuneCountInString(msg.Text); n > MaxMessageLength {
+		return fmt.Errorf("%w: text is %d characters, max %d", ErrInvalidMessage, n, MaxMessageLength)
+	}
+
+	return nil
+}
+
+// NewSourceGUID returns a random version 4 UUID for use as a message's
+// source_guid. It is safe for concurrent use.
+func NewSourceGUID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,174 @@
+package groupme
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var uuidV4Regexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewSourceGUID(t *testing.T) {
+	const goroutines, perGoroutine = 8, 500
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				guid := NewSourceGUID()
+				if !uuidV4Regexp.MatchString(guid) {
+					t.Errorf("NewSourceGUID() = %q, not a version 4 UUID", guid)
+				}
+
+				mu.Lock()
+				if seen[guid] {
+					t.Errorf("NewSourceGUID() repeated %q", guid)
+				}
+				seen[guid] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestEmptyMessageRejected(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeEnvelope(w, http.StatusCreated, CreateMessageResponse{Message: &Message{ID: "m1"}})
+	}))
+	ctx := context.Background()
+
+	const want = "groupme: invalid message: a message needs text or an attachment"
+	if _, err := c.CreateMessage("g1", "guid", ""); !errors.Is(err, ErrInvalidMessage) || err.Error() != want {
+		t.Errorf("CreateMessage: err = %v, want %q", err, want)
+	}
+	if _, err := c.CreateRichMessage(ctx, "g1", OutgoingMessage{}); !errors.Is(err, ErrInvalidMessage) || err.Error() != want {
+		t.Errorf("CreateRichMessage: err = %v, want %q", err, want)
+	}
+	if requests != 0 {
+		t.Errorf("requests = %d, want 0", requests)
+	}
+}
+
+func TestAttachmentOnlyMessage(t *testing.T) {
+	var payload CreateMessagePayload
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+		writeEnvelope(w, http.StatusCreated, CreateMessageResponse{Message: &Message{ID: "m1"}})
+	}))
+
+	msg, err := c.CreateRichMessage(context.Background(), "g1", OutgoingMessage{
+		Attachments: []Attachment{NewImageAttachment("https://i.groupme.com/1")},
+	})
+	if err != nil {
+		t.Fatalf("CreateRichMessage: %v", err)
+	}
+	if msg.ID != "m1" {
+		t.Errorf("message = %+v", msg)
+	}
+	if payload.Message.Text != "" || len(payload.Message.Attachments) != 1 || payload.Message.SourceGUID == "" {
+		t.Errorf("payload = %+v", payload.Message)
+	}
+}
+
+func TestCombineAttachmentsMergesMentions(t *testing.T) {
+	image := NewImageAttachment("https://i.groupme.com/1")
+	first := Attachment{Type: MentionsAttachment, UserIDs: []string{"u1"}, Loci: [][]int{{0, 6}}}
+	second := Attachment{Type: MentionsAttachment, UserIDs: []string{"u2"}, Loci: [][]int{{10, 4}}}
+	location := NewLocationAttachment("Station 1", "41.8", "-71.8")
+
+	combined, err := CombineAttachments(image, first, location, second)
+	if err != nil {
+		t.Fatalf("CombineAttachments: %v", err)
+	}
+
+	want := []Attachment{
+		image,
+		{Type: MentionsAttachment, UserIDs: []string{"u1", "u2"}, Loci: [][]int{{0, 6}, {10, 4}}},
+		location,
+	}
+	if !reflect.DeepEqual(combined, want) {
+		t.Errorf("CombineAttachments =\n%+v\nwant\n%+v", combined, want)
+	}
+	// the caller's attachments are not modified
+	if len(first.UserIDs) != 1 || len(first.Loci) != 1 {
+		t.Errorf("first mentions attachment modified: %+v", first)
+	}
+}
+
+func TestCombineAttachmentsRejected(t *testing.T) {
+	poll := Attachment{Type: PollAttachment, PollID: "p1"}
+	location := NewLocationAttachment("Station 1", "41.8", "-71.8")
+	reply := NewReplyAttachment("m1")
+
+	tests := []struct {
+		name        string
+		attachments []Attachment
+		want        string
+	}{
+		{"two locations", []Attachment{location, location}, "more than one location attachment"},
+		{"two replies", []Attachment{reply, NewImageAttachment("https://i.groupme.com/1"), reply}, "more than one reply attachment"},
+		{"two polls", []Attachment{poll, poll}, "more than one poll attachment"},
+		{"poll with image", []Attachment{NewImageAttachment("https://i.groupme.com/1"), poll}, "a poll cannot be combined with other attachments"},
+		{"poll with mentions", []Attachment{poll, NewEveryoneMention()}, "a poll cannot be combined with other attachments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CombineAttachments(tt.attachments...)
+			if !errors.Is(err, ErrInvalidMessage) || !strings.HasSuffix(err.Error(), tt.want) {
+				t.Errorf("err = %v, want ErrInvalidMessage: %s", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := CombineAttachments(poll); err != nil {
+		t.Errorf("poll alone: %v", err)
+	}
+}
+
+func TestCreateRichMessageCombinesAttachments(t *testing.T) {
+	var requests int
+	var got CreateMessagePayload
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewDecoder(r.Body).Decode(&got)
+		writeEnvelope(w, http.StatusCreated, CreateMessageResponse{Message: &Message{ID: "m1"}})
+	}))
+	ctx := context.Background()
+
+	location := NewLocationAttachment("Station 1", "41.8", "-71.8")
+	if _, err := c.CreateRichMessage(ctx, "g1", OutgoingMessage{Text: "here", Attachments: []Attachment{location, location}}); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("two locations: err = %v, want ErrInvalidMessage", err)
+	}
+	if requests != 0 {
+		t.Fatalf("requests = %d, want 0", requests)
+	}
+
+	msg := OutgoingMessage{
+		Text: "@Alice @Bob",
+		Attachments: []Attachment{
+			{Type: MentionsAttachment, UserIDs: []string{"u1"}, Loci: [][]int{{0, 6}}},
+			{Type: MentionsAttachment, UserIDs: []string{"u2"}, Loci: [][]int{{7, 4}}},
+		},
+	}
+	if _, err := c.CreateRichMessage(ctx, "g1", msg); err != nil {
+		t.Fatalf("CreateRichMessage: %v", err)
+	}
+	if a := got.Message.Attachments; len(a) != 1 || !reflect.DeepEqual(a[0].UserIDs, []string{"u1", "u2"}) {
+		t.Errorf("sent attachments %+v, want one mentions attachment for u1 and u2", a)
+	}
+}
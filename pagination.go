@@ -0,0 +1,113 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+)
+
+// Paginator iterates over the items of a paginated endpoint, fetching one page
+// at a time. Iteration ends at the first empty page or ErrNotModified, which
+// is how GroupMe reports the end of a list, or after a short page if a page
+// size is set with WithPageSize.
+//
+// Custom endpoints can be paginated by building a Paginator around Do.
+type Paginator[T any] struct {
+	fetch    func(ctx context.Context) ([]T, error)
+	pageSize int
+
+	page    []T
+	current T
+	done    bool
+	err     error
+}
+
+// NewPagePaginator returns a Paginator over an endpoint paginated by page
+// number. fetch is called with page 1, 2, ...
+func NewPagePaginator[T any](fetch func(ctx context.Context, page int) ([]T, error)) *Paginator[T] {
+	page := 0
+	return &Paginator[T]{
+		fetch: func(ctx context.Context) ([]T, error) {
+			page++
+			return fetch(ctx, page)
+		},
+	}
+}
+
+// NewCursorPaginator returns a Paginator over an endpoint paginated by cursor,
+// such as before_id. fetch is called first with cursor, then with the cursor
+// of the last item of each page, as returned by cursorOf.
+func NewCursorPaginator[T any](cursor string, fetch func(ctx context.Context, cursor string) ([]T, error), cursorOf func(T) string) *Paginator[T] {
+	return &Paginator[T]{
+		fetch: func(ctx context.Context) ([]T, error) {
+			items, err := fetch(ctx, cursor)
+			if err == nil && len(items) > 0 {
+				cursor = cursorOf(items[len(items)-1])
+			}
+			return items, err
+		},
+	}
+}
+
+// WithPageSize sets the number of items in a full page, so that a page with
+// fewer items is known to be the last without fetching another.
+func (p *Paginator[T]) WithPageSize(n int) *Paginator[T] {
+	p.pageSize = n
+	return p
+}
+
+// Next advances the Paginator to the next item, fetching another page when
+// needed. It returns false when the list is exhausted or an error occurs.
+func (p *Paginator[T]) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+
+	if len(p.page) == 0 {
+		if p.done {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		items, err := p.fetch(ctx)
+		if err != nil {
+			if errors.Is(err, ErrNotModified) {
+				p.done = true
+				return false
+			}
+			p.err = err
+			return false
+		}
+		if len(items) == 0 {
+			p.done = true
+			return false
+		}
+
+		p.page = items
+		if p.pageSize > 0 && len(items) < p.pageSize {
+			p.done = true
+		}
+	}
+
+	p.current = p.page[0]
+	p.page = p.page[1:]
+	return true
+}
+
+// HasMore returns whether there may be more items: false once the end of the
+// list has been reached and every fetched item consumed, or after an error.
+func (p *Paginator[T]) HasMore() bool {
+	return p.err == nil && (len(p.page) > 0 || !p.done)
+}
+
+// Item returns the current item.
+func (p *Paginator[T]) Item() T {
+	return p.current
+}
+
+// Err returns the first error encountered by the Paginator, if any.
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
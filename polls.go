@@ -0,0 +1,63 @@
+package groupme
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Poll is a poll posted in a group or direct message conversation.
+type Poll struct {
+	ID             string       `json:"id"`
+	Subject        string       `json:"subject"`
+	OwnerID        string       `json:"owner_id"`
+	ConversationID string       `json:"conversation_id"`
+	CreatedAt      int          `json:"created_at"`
+	Expiration     int          `json:"expiration"`
+	Status         string       `json:"status"`
+	Type           string       `json:"type"`
+	Visibility     string       `json:"visibility"`
+	Options        []PollOption `json:"options"`
+
+	// UserVotes are the IDs of the options the authenticated user voted for.
+	UserVotes []string `json:"-"`
+}
+
+// PollOption is an option of a Poll and its current tally.
+type PollOption struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Votes    int      `json:"votes"`
+	VoterIDs []string `json:"voter_ids"`
+}
+
+// GetPoll retrieves a poll with its current results. GroupMe does not
+// document this endpoint, so it may change without notice.
+func (c *Client) GetPoll(ctx context.Context, conversationID, pollID string) (*Poll, error) {
+	var resp struct {
+		Poll struct {
+			Data      Poll     `json:"data"`
+			UserVotes []string `json:"user_votes"`
+		} `json:"poll"`
+	}
+	err := c.doRequest(ctx, "polls.get", http.MethodGet, fmt.Sprintf("/poll/%s/%s", conversationID, pollID), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	poll := resp.Poll.Data
+	poll.UserVotes = resp.Poll.UserVotes
+	return &poll, nil
+}
+
+// MessagePoll retrieves the poll attached to a group message, with its current
+// results. It returns an error if the message has no poll attachment.
+func (c *Client) MessagePoll(ctx context.Context, m *Message) (*Poll, error) {
+	for _, a := range m.Attachments {
+		if ref, ok := a.AsPoll(); ok {
+			return c.GetPoll(ctx, m.GroupID, ref.PollID)
+		}
+	}
+
+	return nil, fmt.Errorf("groupme: message %s has no poll attachment", m.ID)
+}
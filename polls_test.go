@@ -0,0 +1,57 @@
+package groupme
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMessagePoll(t *testing.T) {
+	fixture := serveFixture(t, "poll.json")
+	var path string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fixture.ServeHTTP(w, r)
+	}))
+
+	m := &Message{ID: "m1", GroupID: "12345678", Attachments: []Attachment{{Type: PollAttachment, PollID: "98765"}}}
+	poll, err := c.MessagePoll(context.Background(), m)
+	if err != nil {
+		t.Fatalf("MessagePoll: %v", err)
+	}
+	if want := "/poll/12345678/98765"; path != want {
+		t.Errorf("path = %s, want %s", path, want)
+	}
+
+	want := &Poll{
+		ID:             "98765",
+		Subject:        "Drill night?",
+		OwnerID:        "1111",
+		ConversationID: "12345678",
+		CreatedAt:      1700000000,
+		Expiration:     1700086400,
+		Status:         "active",
+		Type:           "single",
+		Visibility:     "public",
+		Options: []PollOption{
+			{ID: "1", Title: "Tuesday", Votes: 3, VoterIDs: []string{"1111", "2222", "3333"}},
+			{ID: "2", Title: "Thursday", Votes: 1, VoterIDs: []string{"4444"}},
+			{ID: "3", Title: "Neither"},
+		},
+		UserVotes: []string{"1"},
+	}
+	if !reflect.DeepEqual(poll, want) {
+		t.Errorf("poll =\n%+v\nwant\n%+v", poll, want)
+	}
+}
+
+func TestMessagePollWithoutPoll(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+
+	if _, err := c.MessagePoll(context.Background(), &Message{ID: "m1"}); err == nil {
+		t.Fatal("MessagePoll of a message without a poll: want error")
+	}
+}
@@ -0,0 +1,102 @@
+package groupme
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// PowerupsURL is the URL of GroupMe's powerup pack listing.
+const PowerupsURL = "https://powerup.groupme.com/powerups"
+
+// PowerupPack is a pack of GroupMe emoji. An EmojiAttachment's Charmap entries
+// are [pack ID, index] pairs, where index is a position in Transliterations
+// and in the pack's sprite sheets.
+type PowerupPack struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	StoreIcon   string      `json:"store_icon"`
+	CreatedAt   int         `json:"created_at"`
+	UpdatedAt   int         `json:"updated_at"`
+	Meta        PowerupMeta `json:"meta"`
+}
+
+// PowerupMeta describes the contents of a PowerupPack.
+type PowerupMeta struct {
+	PackID           int             `json:"pack_id"`
+	Transliterations []string        `json:"transliterations"`
+	Inline           []PowerupSprite `json:"inline"`
+	Keyboard         []PowerupSprite `json:"keyboard"`
+}
+
+// PowerupSprite is a sprite sheet of a pack's emoji at one size.
+type PowerupSprite struct {
+	ImageURL string `json:"image_url"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+}
+
+// ListPowerupPacks retrieves all powerup packs.
+func (c *Client) ListPowerupPacks(ctx context.Context) ([]PowerupPack, error) {
+	packs, _, err := c.ListPowerupPacksIfChanged(ctx, "")
+	return packs, err
+}
+
+// ListPowerupPacksIfChanged retrieves all powerup packs unless they are
+// unchanged since the response tagged etag, in which case ErrNotModified is
+// returned. The returned etag can be passed to later calls; it is the one
+// given whenever no new packs were retrieved.
+func (c *Client) ListPowerupPacksIfChanged(ctx context.Context, etag string) ([]PowerupPack, string, error) {
+	var packs []PowerupPack
+	err := c.instrument(ctx, RequestInfo{Endpoint: "powerups.list", Method: http.MethodGet}, func(ctx context.Context) (int, int, error) {
+		var status int
+		var err error
+		packs, etag, status, err = c.listPowerupPacks(ctx, etag)
+		return status, 0, err
+	})
+	return packs, etag, err
+}
+
+func (c *Client) listPowerupPacks(ctx context.Context, etag string) ([]PowerupPack, string, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, PowerupsURL, nil)
+	if err != nil {
+		return nil, etag, 0, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	c.setHeaders(req)
+
+	// send request, read body
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return nil, etag, 0, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, etag, resp.StatusCode, err
+	}
+
+	// exit early on error, keeping the caller's etag
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		return nil, etag, resp.StatusCode, ErrNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, etag, resp.StatusCode, &APIError{StatusCode: resp.StatusCode}
+	}
+
+	// parse response
+	var powerups struct {
+		Powerups []PowerupPack `json:"powerups"`
+	}
+	err = json.Unmarshal(body, &powerups)
+	if err != nil {
+		return nil, etag, resp.StatusCode, err
+	}
+
+	return powerups.Powerups, resp.Header.Get("ETag"), resp.StatusCode, nil
+}
@@ -0,0 +1,93 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+const powerupsBody = `{"powerups":[{"id":"1","name":"Classic","type":"emoji","meta":{"pack_id":1,"transliterations":["smile","frown"]}}]}`
+
+func TestListPowerupPacks(t *testing.T) {
+	c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "powerup.groupme.com" || r.URL.Path != "/powerups" {
+			t.Errorf("request = %s%s", r.Host, r.URL.Path)
+		}
+		if h := r.Header.Get("If-None-Match"); h != "" {
+			t.Errorf("If-None-Match = %q, want none", h)
+		}
+		io.WriteString(w, powerupsBody)
+	}))
+
+	packs, err := c.ListPowerupPacks(context.Background())
+	if err != nil {
+		t.Fatalf("ListPowerupPacks: %v", err)
+	}
+	if len(packs) != 1 || packs[0].Name != "Classic" || packs[0].Meta.PackID != 1 || len(packs[0].Meta.Transliterations) != 2 {
+		t.Errorf("packs = %+v", packs)
+	}
+}
+
+func TestListPowerupPacksIfChanged(t *testing.T) {
+	version := `"v1"`
+	var sent []string
+	c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == version {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", version)
+		io.WriteString(w, powerupsBody)
+	}))
+	ctx := context.Background()
+
+	packs, etag, err := c.ListPowerupPacksIfChanged(ctx, "")
+	if err != nil || len(packs) != 1 || etag != `"v1"` {
+		t.Fatalf("first call = %d packs, %q, %v; want 1 pack and \"v1\"", len(packs), etag, err)
+	}
+
+	// unchanged: the etag survives the 304
+	packs, etag, err = c.ListPowerupPacksIfChanged(ctx, etag)
+	if !errors.Is(err, ErrNotModified) || packs != nil || etag != `"v1"` {
+		t.Fatalf("unchanged = %v, %q, %v; want nil, \"v1\", ErrNotModified", packs, etag, err)
+	}
+	packs, etag, err = c.ListPowerupPacksIfChanged(ctx, etag)
+	if !errors.Is(err, ErrNotModified) || etag != `"v1"` {
+		t.Fatalf("unchanged again = %v, %q, %v", packs, etag, err)
+	}
+
+	// changed: new packs under a new etag
+	version = `"v2"`
+	packs, etag, err = c.ListPowerupPacksIfChanged(ctx, etag)
+	if err != nil || len(packs) != 1 || etag != `"v2"` {
+		t.Fatalf("changed = %d packs, %q, %v; want 1 pack and \"v2\"", len(packs), etag, err)
+	}
+
+	want := []string{"", `"v1"`, `"v1"`, `"v1"`}
+	if len(sent) != len(want) {
+		t.Fatalf("If-None-Match sent %q, want %q", sent, want)
+	}
+	for i := range want {
+		if sent[i] != want[i] {
+			t.Errorf("request %d: If-None-Match = %q, want %q", i+1, sent[i], want[i])
+		}
+	}
+}
+
+func TestListPowerupPacksIfChangedError(t *testing.T) {
+	c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	_, etag, err := c.ListPowerupPacksIfChanged(context.Background(), `"v1"`)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want an *APIError with status 503", err)
+	}
+	if etag != `"v1"` {
+		t.Errorf("etag = %q, want \"v1\" kept", etag)
+	}
+}
@@ -0,0 +1,189 @@
+package groupme
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the request
+// latency histogram of PrometheusMetrics.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Help texts of the metrics kept by PrometheusMetrics.
+const (
+	requestsHelp    = "GroupMe API requests."
+	errorsHelp      = "GroupMe API requests that returned an error."
+	durationsHelp   = "GroupMe API request latency, including retries."
+	rateLimitedHelp = "GroupMe API requests rejected as rate limited."
+	pagesHelp       = "Pages fetched while walking whole histories."
+)
+
+// PrometheusMetrics is a Metrics that keeps Prometheus-style counters and a
+// latency histogram of a Client's requests, and serves them in the Prometheus
+// text exposition format, so it can be scraped directly or mounted next to
+// an existing registry's handler. It reports:
+//
+//	groupme_requests_total{endpoint,status_class}
+//	groupme_request_errors_total{endpoint}
+//	groupme_request_duration_seconds{endpoint} (histogram)
+//	groupme_rate_limited_total{endpoint}
+//	groupme_pages_fetched_total{operation}
+//
+// Built with the prometheus build tag, PrometheusMetrics also implements
+// prometheus.Collector, so it can be registered with a prometheus.Registry
+// and served by its handler instead. Without the tag this package does not
+// depend on the Prometheus client library.
+//
+// The zero value is not usable; use NewPrometheusMetrics.
+type PrometheusMetrics struct {
+	buckets []float64
+
+	mu          sync.Mutex
+	requests    map[[2]string]uint64
+	errors      map[string]uint64
+	durations   map[string]*histogram
+	rateLimited map[string]uint64
+	pages       map[string]uint64
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewPrometheusMetrics returns an empty PrometheusMetrics.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		buckets:     DefaultLatencyBuckets,
+		requests:    map[[2]string]uint64{},
+		errors:      map[string]uint64{},
+		durations:   map[string]*histogram{},
+		rateLimited: map[string]uint64{},
+		pages:       map[string]uint64{},
+	}
+}
+
+// ObserveRequest implements Metrics.
+func (m *PrometheusMetrics) ObserveRequest(endpoint, statusClass string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[[2]string{endpoint, statusClass}]++
+	if err != nil {
+		m.errors[endpoint]++
+	}
+
+	h, ok := m.durations[endpoint]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.durations[endpoint] = h
+	}
+	seconds := duration.Seconds()
+	for i, le := range m.buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ObserveRateLimit implements RateLimitObserver.
+func (m *PrometheusMetrics) ObserveRateLimit(endpoint string, wait time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rateLimited[endpoint]++
+}
+
+// ObservePage implements PageObserver.
+func (m *PrometheusMetrics) ObservePage(operation string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pages[operation]++
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# HELP groupme_requests_total %s\n# TYPE groupme_requests_total counter\n", requestsHelp)
+	for _, k := range m.requestKeys() {
+		fmt.Fprintf(&b, "groupme_requests_total{endpoint=%s,status_class=%s} %d\n", labelValue(k[0]), labelValue(k[1]), m.requests[k])
+	}
+
+	writeCounter(&b, "groupme_request_errors_total", errorsHelp, "endpoint", m.errors)
+
+	fmt.Fprintf(&b, "# HELP groupme_request_duration_seconds %s\n# TYPE groupme_request_duration_seconds histogram\n", durationsHelp)
+	for _, k := range sortedKeys(m.durations) {
+		h, endpoint := m.durations[k], labelValue(k)
+		for i, le := range m.buckets {
+			fmt.Fprintf(&b, "groupme_request_duration_seconds_bucket{endpoint=%s,le=\"%g\"} %d\n", endpoint, le, h.counts[i])
+		}
+		fmt.Fprintf(&b, "groupme_request_duration_seconds_bucket{endpoint=%s,le=\"+Inf\"} %d\n", endpoint, h.count)
+		fmt.Fprintf(&b, "groupme_request_duration_seconds_sum{endpoint=%s} %g\n", endpoint, h.sum)
+		fmt.Fprintf(&b, "groupme_request_duration_seconds_count{endpoint=%s} %d\n", endpoint, h.count)
+	}
+
+	writeCounter(&b, "groupme_rate_limited_total", rateLimitedHelp, "endpoint", m.rateLimited)
+	writeCounter(&b, "groupme_pages_fetched_total", pagesHelp, "operation", m.pages)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// requestKeys returns the endpoint and status class pairs of the requests
+// counted so far, in order. m.mu must be held.
+func (m *PrometheusMetrics) requestKeys() [][2]string {
+	keys := make([][2]string, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
+
+// writeCounter writes a counter with one label in the text exposition format.
+func writeCounter(b *strings.Builder, name, help, label string, values map[string]uint64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, k := range sortedKeys(values) {
+		fmt.Fprintf(b, "%s{%s=%s} %d\n", name, label, labelValue(k), values[k])
+	}
+}
+
+// labelEscaper escapes label values as the text exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelValue returns v quoted as a label value.
+func labelValue(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
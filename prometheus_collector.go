@@ -0,0 +1,54 @@
+//go:build prometheus
+
+package groupme
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	requestsDesc    = prometheus.NewDesc("groupme_requests_total", requestsHelp, []string{"endpoint", "status_class"}, nil)
+	errorsDesc      = prometheus.NewDesc("groupme_request_errors_total", errorsHelp, []string{"endpoint"}, nil)
+	durationsDesc   = prometheus.NewDesc("groupme_request_duration_seconds", durationsHelp, []string{"endpoint"}, nil)
+	rateLimitedDesc = prometheus.NewDesc("groupme_rate_limited_total", rateLimitedHelp, []string{"endpoint"}, nil)
+	pagesDesc       = prometheus.NewDesc("groupme_pages_fetched_total", pagesHelp, []string{"operation"}, nil)
+)
+
+var _ prometheus.Collector = (*PrometheusMetrics)(nil)
+
+// Describe implements prometheus.Collector.
+func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- requestsDesc
+	ch <- errorsDesc
+	ch <- durationsDesc
+	ch <- rateLimitedDesc
+	ch <- pagesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (m *PrometheusMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, k := range m.requestKeys() {
+		ch <- prometheus.MustNewConstMetric(requestsDesc, prometheus.CounterValue, float64(m.requests[k]), k[0], k[1])
+	}
+	collectCounter(ch, errorsDesc, m.errors)
+
+	for _, endpoint := range sortedKeys(m.durations) {
+		h := m.durations[endpoint]
+		buckets := make(map[float64]uint64, len(m.buckets))
+		for i, le := range m.buckets {
+			buckets[le] = h.counts[i]
+		}
+		ch <- prometheus.MustNewConstHistogram(durationsDesc, h.count, h.sum, buckets, endpoint)
+	}
+
+	collectCounter(ch, rateLimitedDesc, m.rateLimited)
+	collectCounter(ch, pagesDesc, m.pages)
+}
+
+// collectCounter sends a counter with one label to ch.
+func collectCounter(ch chan<- prometheus.Metric, desc *prometheus.Desc, values map[string]uint64) {
+	for _, k := range sortedKeys(values) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(values[k]), k)
+	}
+}
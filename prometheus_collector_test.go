@@ -0,0 +1,32 @@
+//go:build prometheus
+
+package groupme
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusMetricsCollector(t *testing.T) {
+	m := NewPrometheusMetrics()
+	m.ObserveRequest("messages.index", "2xx", 30*time.Millisecond, nil)
+	m.ObserveRequest("messages.index", "5xx", 3*time.Second, ErrInternalServerError)
+	m.ObserveRateLimit("messages.index", time.Second)
+	m.ObservePage("messages.all")
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(m); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	// the registry exposes what WriteTo does
+	var want strings.Builder
+	m.WriteTo(&want)
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want.String())); err != nil {
+		t.Error(err)
+	}
+}
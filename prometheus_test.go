@@ -0,0 +1,82 @@
+package groupme
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics()
+	m.ObserveRequest("messages.index", "2xx", 30*time.Millisecond, nil)
+	m.ObserveRequest("messages.index", "5xx", 3*time.Second, errors.New("boom"))
+	m.ObserveRateLimit("messages.index", time.Second)
+	m.ObservePage("messages.all")
+
+	var b strings.Builder
+	if _, err := m.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	want := `# HELP groupme_requests_total GroupMe API requests.
+# TYPE groupme_requests_total counter
+groupme_requests_total{endpoint="messages.index",status_class="2xx"} 1
+groupme_requests_total{endpoint="messages.index",status_class="5xx"} 1
+# HELP groupme_request_errors_total GroupMe API requests that returned an error.
+# TYPE groupme_request_errors_total counter
+groupme_request_errors_total{endpoint="messages.index"} 1
+# HELP groupme_request_duration_seconds GroupMe API request latency, including retries.
+# TYPE groupme_request_duration_seconds histogram
+groupme_request_duration_seconds_bucket{endpoint="messages.index",le="0.05"} 1
+groupme_request_duration_seconds_bucket{endpoint="messages.index",le="0.1"} 1
+groupme_request_duration_seconds_bucket{endpoint="messages.index",le="0.25"} 1
+groupme_request_duration_seconds_bucket{endpoint="messages.index",le="0.5"} 1
+groupme_request_duration_seconds_bucket{endpoint="messages.index",le="1"} 1
+groupme_request_duration_seconds_bucket{endpoint="messages.index",le="2.5"} 1
+groupme_request_duration_seconds_bucket{endpoint="messages.index",le="5"} 2
+groupme_request_duration_seconds_bucket{endpoint="messages.index",le="10"} 2
+groupme_request_duration_seconds_bucket{endpoint="messages.index",le="+Inf"} 2
+groupme_request_duration_seconds_sum{endpoint="messages.index"} 3.03
+groupme_request_duration_seconds_count{endpoint="messages.index"} 2
+# HELP groupme_rate_limited_total GroupMe API requests rejected as rate limited.
+# TYPE groupme_rate_limited_total counter
+groupme_rate_limited_total{endpoint="messages.index"} 1
+# HELP groupme_pages_fetched_total Pages fetched while walking whole histories.
+# TYPE groupme_pages_fetched_total counter
+groupme_pages_fetched_total{operation="messages.all"} 1
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteTo =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPrometheusLabelEscaping(t *testing.T) {
+	m := NewPrometheusMetrics()
+	m.ObservePage("a\\b \"c\"\nd\té")
+
+	var b strings.Builder
+	m.WriteTo(&b)
+
+	// only backslash, double quote and newline are escaped
+	want := `groupme_pages_fetched_total{operation="a\\b \"c\"\nd` + "\t" + `é"} 1`
+	if !strings.Contains(b.String(), want+"\n") {
+		t.Errorf("WriteTo =\n%s\nwant a line\n%s", b.String(), want)
+	}
+}
+
+func TestPrometheusMetricsServeHTTP(t *testing.T) {
+	m := NewPrometheusMetrics()
+	m.ObservePage("messages.all")
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `groupme_pages_fetched_total{operation="messages.all"} 1`) {
+		t.Errorf("body =\n%s", rec.Body.String())
+	}
+}
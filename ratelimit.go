@@ -0,0 +1,113 @@
+package groupme
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRateLimitRetries is the most times a rate limited request is retried.
+const maxRateLimitRetries = 3
+
+// defaultRateLimitWait is how long to wait before retrying a rate limited
+// request when GroupMe does not say. It is a variable for tests.
+var defaultRateLimitWait = time.Second
+
+// RateLimiter paces the requests a Client sends.
+type RateLimiter interface {
+	// Wait blocks until a request may be sent, or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// NewRateLimiter returns a RateLimiter allowing perSecond requests a second
+// on average, in bursts of up to burst requests. A perSecond of zero or less
+// means no limit.
+func NewRateLimiter(perSecond float64, burst int) RateLimiter {
+	if !(perSecond > 0) {
+		return unlimited{}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		burst:    burst,
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// WithRateLimit sets the Client's RateLimiter to NewRateLimiter(perSecond,
+// burst).
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.RateLimiter = NewRateLimiter(perSecond, burst)
+	}
+}
+
+// unlimited is a RateLimiter that never waits.
+type unlimited struct{}
+
+func (unlimited) Wait(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// tokenBucket is a token bucket RateLimiter.
+type tokenBucket struct {
+	interval time.Duration
+	burst    int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+	b.last = now
+
+	// take a token now, waiting for it to accrue if there is none
+	b.tokens--
+	wait := time.Duration(-b.tokens * float64(b.interval))
+	b.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, wait); err != nil {
+		// give the token back
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// isRateLimited returns whether status means a request was rate limited.
+func isRateLimited(status int) bool {
+	return status == http.StatusTooManyRequests || status == StatusEnhanceYourCalm
+}
+
+// parseRetryAfter parses a Retry-After header, given in seconds or as a date.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
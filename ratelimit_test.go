@@ -0,0 +1,144 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimiterUnlimited(t *testing.T) {
+	for _, perSecond := range []float64{0, -1, math.NaN()} {
+		l := NewRateLimiter(perSecond, 1)
+		start := time.Now()
+		for i := 0; i < 100; i++ {
+			if err := l.Wait(context.Background()); err != nil {
+				t.Fatalf("NewRateLimiter(%v, 1).Wait: %v", perSecond, err)
+			}
+		}
+		if d := time.Since(start); d > 100*time.Millisecond {
+			t.Errorf("NewRateLimiter(%v, 1): 100 waits took %s", perSecond, d)
+		}
+	}
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := NewRateLimiter(20, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d > 25*time.Millisecond {
+		t.Errorf("burst of 3 took %s, want no wait", d)
+	}
+
+	// the fourth request waits for a token to accrue
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("fourth request after %s, want about 50ms", d)
+	}
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	l := NewRateLimiter(0.001, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// rateLimitRecorder is a Metrics that records rate limit waits.
+type rateLimitRecorder struct {
+	waits []time.Duration
+}
+
+func (r *rateLimitRecorder) ObserveRequest(endpoint, statusClass string, duration time.Duration, err error) {
+}
+
+func (r *rateLimitRecorder) ObserveRateLimit(endpoint string, wait time.Duration) {
+	r.waits = append(r.waits, wait)
+}
+
+// shortRateLimitWait shortens the default rate limit wait for a test.
+func shortRateLimitWait(t *testing.T) {
+	wait := defaultRateLimitWait
+	defaultRateLimitWait = time.Millisecond
+	t.Cleanup(func() { defaultRateLimitWait = wait })
+}
+
+func TestRateLimitedRetried(t *testing.T) {
+	shortRateLimitWait(t)
+
+	var requests int
+	metrics := &rateLimitRecorder{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= 2 {
+			w.Header().Set("Retry-After", "0")
+			writeMetaError(w, http.StatusTooManyRequests)
+			return
+		}
+		writeEnvelope(w, http.StatusOK, nil)
+	}), WithMetrics(metrics))
+
+	if err := c.LikeMessage("g1", "m1"); err != nil {
+		t.Fatalf("LikeMessage: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+	// Retry-After: 0 says nothing, so the default wait is used
+	if want := []time.Duration{time.Millisecond, time.Millisecond}; !reflect.DeepEqual(metrics.waits, want) {
+		t.Errorf("waits = %v, want %v", metrics.waits, want)
+	}
+}
+
+func TestRateLimitedForever(t *testing.T) {
+	shortRateLimitWait(t)
+
+	var requests int
+	metrics := &rateLimitRecorder{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests%2 == 0 {
+			w.Header().Set("Retry-After", "1")
+		}
+		writeMetaError(w, StatusEnhanceYourCalm)
+	}), WithMetrics(metrics), WithRetryPolicy(fastRetries))
+
+	start := time.Now()
+	err := c.LikeMessage("g1", "m1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrEnhanceYourCalm) {
+		t.Fatalf("err = %v, want a 420 *APIError", err)
+	}
+	// the last response's Retry-After is reported to the caller
+	if apiErr.RetryAfter != time.Second {
+		t.Errorf("RetryAfter = %s, want 1s", apiErr.RetryAfter)
+	}
+	// three retries, not counted against the RetryPolicy, then the error
+	if requests != maxRateLimitRetries+1 {
+		t.Errorf("requests = %d, want %d", requests, maxRateLimitRetries+1)
+	}
+	want := []time.Duration{time.Millisecond, time.Second, time.Millisecond}
+	if !reflect.DeepEqual(metrics.waits, want) {
+		t.Errorf("waits = %v, want %v", metrics.waits, want)
+	}
+	if d := time.Since(start); d < time.Second {
+		t.Errorf("gave up after %s, want Retry-After honored", d)
+	}
+}
@@ -0,0 +1,98 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries requests that fail transiently:
+// with a network error, or with a 5xx response. Requests that may already
+// have been carried out are not repeated: POSTs are only retried on 502, 503
+// and 504 responses, which GroupMe sends before processing a request.
+type RetryPolicy struct {
+	// MaxAttempts is the most times a request is sent, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the wait before the first retry. It doubles with every
+	// further retry, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Jitter randomizes each wait by up to this fraction of it, in either
+	// direction, so that many clients do not retry in lockstep.
+	Jitter float64
+}
+
+// DefaultRetryPolicy is the RetryPolicy of clients made by NewClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	Jitter:      0.2,
+}
+
+// WithRetryPolicy sets the Client's RetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithoutRetries disables retries.
+func WithoutRetries() Option {
+	return func(c *Client) {
+		c.RetryPolicy = RetryPolicy{}
+	}
+}
+
+// retryable returns whether a request that failed with status (0 if no
+// response was received) and err may be sent again.
+func (p RetryPolicy) retryable(method string, status int, err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	switch {
+	case status == 0:
+		// only failures to get a response, not to build the request
+		var urlErr *url.Error
+		return errors.As(err, &urlErr) && method != http.MethodPost
+	case status == http.StatusBadGateway, status == http.StatusServiceUnavailable, status == http.StatusGatewayTimeout:
+		return true
+	case status >= 500:
+		return method != http.MethodPost
+	}
+	return false
+}
+
+// delay returns the wait before retry number n, starting at 1.
+func (p RetryPolicy) delay(n int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < n && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,131 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRetryable(t *testing.T) {
+	netErr := &url.Error{Op: "Get", URL: "https://api.groupme.com", Err: errors.New("connection refused")}
+	apiErr := func(status int) error { return newAPIError(status, Meta{}) }
+
+	tests := []struct {
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{http.MethodGet, 0, netErr, true},
+		{http.MethodPost, 0, netErr, false},
+		{http.MethodGet, 0, errors.New("bad request body"), false},
+		{http.MethodGet, 0, fmt.Errorf("send: %w", context.Canceled), false},
+		{http.MethodGet, 0, context.DeadlineExceeded, false},
+		{http.MethodGet, http.StatusOK, nil, false},
+		{http.MethodGet, http.StatusInternalServerError, apiErr(500), true},
+		{http.MethodPost, http.StatusInternalServerError, apiErr(500), false},
+		{http.MethodPost, http.StatusBadGateway, apiErr(502), true},
+		{http.MethodPost, http.StatusServiceUnavailable, apiErr(503), true},
+		{http.MethodPost, http.StatusGatewayTimeout, apiErr(504), true},
+		{http.MethodDelete, http.StatusInternalServerError, apiErr(500), true},
+		{http.MethodGet, http.StatusNotFound, apiErr(404), false},
+		{http.MethodGet, http.StatusTooManyRequests, apiErr(429), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d %v", tt.method, tt.status, tt.err), func(t *testing.T) {
+			if got := DefaultRetryPolicy.retryable(tt.method, tt.status, tt.err); got != tt.want {
+				t.Errorf("retryable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.2}
+
+	tests := []struct {
+		n    int
+		want time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{50, time.Second},
+	}
+
+	for _, tt := range tests {
+		lo := time.Duration(float64(tt.want) * (1 - p.Jitter))
+		hi := time.Duration(float64(tt.want) * (1 + p.Jitter))
+		for i := 0; i < 100; i++ {
+			if d := p.delay(tt.n); d < lo || d > hi {
+				t.Fatalf("delay(%d) = %s, want within [%s, %s]", tt.n, d, lo, hi)
+			}
+		}
+
+		noJitter := p
+		noJitter.Jitter = 0
+		if d := noJitter.delay(tt.n); d != tt.want {
+			t.Errorf("delay(%d) without jitter = %s, want %s", tt.n, d, tt.want)
+		}
+	}
+}
+
+// fastRetries retries quickly, for tests.
+var fastRetries = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+func TestRetryGET(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			writeMetaError(w, http.StatusServiceUnavailable)
+			return
+		}
+		writeEnvelope(w, http.StatusOK, map[string]interface{}{"count": 0, "messages": []Message{}})
+	}), WithRetryPolicy(fastRetries))
+
+	if _, err := c.GetMessages("g1", "", "", "", ""); err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeMetaError(w, http.StatusServiceUnavailable)
+	}), WithRetryPolicy(fastRetries))
+
+	if _, err := c.GetMessages("g1", "", "", "", ""); !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("err = %v, want ErrServiceUnavailable", err)
+	}
+	if requests != fastRetries.MaxAttempts {
+		t.Errorf("requests = %d, want %d", requests, fastRetries.MaxAttempts)
+	}
+}
+
+func TestNoRetryPOST(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeMetaError(w, http.StatusInternalServerError)
+	}), WithRetryPolicy(fastRetries))
+
+	// the message may have been created, so it is not sent again
+	if _, err := c.CreateMessage("g1", "guid", "hi"); !errors.Is(err, ErrInternalServerError) {
+		t.Fatalf("err = %v, want ErrInternalServerError", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
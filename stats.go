@@ -0,0 +1,136 @@
+package groupme
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// GroupStatsResult is the activity of a group over a period, keyed by user ID.
+type GroupStatsResult struct {
+	TotalMessages int
+	MessagesBy    map[string]int
+	LikesGiven    map[string]int
+	LikesReceived map[string]int
+}
+
+// GroupStats aggregates message and like counts for messages in a group created
+// after since.
+func (c *Client) GroupStats(ctx context.Context, groupID string, since time.Time) (GroupStatsResult, error) {
+	stats := GroupStatsResult{
+		MessagesBy:    map[string]int{},
+		LikesGiven:    map[string]int{},
+		LikesReceived: map[string]int{},
+	}
+
+	it := c.IterateMessages(groupID)
+	for it.Next(ctx) {
+		message := it.Message()
+		if !message.CreatedAtTime().After(since) {
+			break
+		}
+
+		stats.TotalMessages++
+		stats.MessagesBy[message.UserID]++
+		stats.LikesReceived[message.UserID] += len(message.FavoritedBy)
+		for _, userID := range message.FavoritedBy {
+			stats.LikesGiven[userID]++
+		}
+	}
+	if err := it.Err(); err != nil {
+		return GroupStatsResult{}, err
+	}
+
+	return stats, nil
+}
+
+// InactiveMembers returns the members of a group who have sent no message
+// created after since, sorted by membership ID.
+func (c *Client) InactiveMembers(ctx context.Context, groupID string, since time.Time) ([]Member, error) {
+	group, err := c.GetGroupWithContext(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := map[string]bool{}
+	it := c.IterateMessages(groupID)
+	for it.Next(ctx) {
+		message := it.Message()
+		if !message.CreatedAtTime().After(since) {
+			break
+		}
+		active[message.UserID] = true
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	var inactive []Member
+	for _, member := range group.Members {
+		if !active[member.UserID] {
+			inactive = append(inactive, *member)
+		}
+	}
+	sort.Slice(inactive, func(i, j int) bool {
+		return inactive[i].ID < inactive[j].ID
+	})
+
+	return inactive, nil
+}
+
+// SharedLocation is a location shared in a group.
+type SharedLocation struct {
+	MessageID string
+	UserID    string
+	Name      string
+	SentAt    time.Time
+
+	LocationName string
+	Lat          float64
+	Lng          float64
+}
+
+// GroupLocations returns the locations shared in a group in messages created
+// after since, newest first. Locations with unparseable coordinates are
+// skipped.
+func (c *Client) GroupLocations(ctx context.Context, groupID string, since time.Time) ([]SharedLocation, error) {
+	var locations []SharedLocation
+
+	it := c.IterateMessages(groupID)
+	for it.Next(ctx) {
+		message := it.Message()
+		if !message.CreatedAtTime().After(since) {
+			break
+		}
+
+		for _, a := range message.Attachments {
+			if a.Type != LocationAttachment {
+				continue
+			}
+			lat, err := strconv.ParseFloat(a.Lat, 64)
+			if err != nil {
+				continue
+			}
+			lng, err := strconv.ParseFloat(a.Lng, 64)
+			if err != nil {
+				continue
+			}
+
+			locations = append(locations, SharedLocation{
+				MessageID:    message.ID,
+				UserID:       message.UserID,
+				Name:         message.Name,
+				SentAt:       message.CreatedAtTime(),
+				LocationName: a.Name,
+				Lat:          lat,
+				Lng:          lng,
+			})
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
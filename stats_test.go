@@ -0,0 +1,145 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGroupLocations(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h := newFakeHistory(150, start)
+	h.messages[9].Attachments = []Attachment{NewLocationAttachment("too old", "1", "2")}
+	h.messages[119].Attachments = []Attachment{NewLocationAttachment("Station 1", "41.8486", "-71.8853")}
+	h.messages[139].Attachments = []Attachment{
+		NewImageAttachment("https://i.groupme.com/1"),
+		NewLocationAttachment("bad", "north", "-71"),
+		NewLocationAttachment("Station 2", "41.9", "-71.9"),
+	}
+	c := newTestClient(t, h)
+
+	locations, err := c.GroupLocations(context.Background(), "g1", start.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("GroupLocations: %v", err)
+	}
+
+	want := []SharedLocation{
+		{MessageID: "140", UserID: "u2", Name: "", SentAt: start.Add(139 * time.Minute).UTC(), LocationName: "Station 2", Lat: 41.9, Lng: -71.9},
+		{MessageID: "120", UserID: "u0", Name: "", SentAt: start.Add(119 * time.Minute).UTC(), LocationName: "Station 1", Lat: 41.8486, Lng: -71.8853},
+	}
+	if !reflect.DeepEqual(locations, want) {
+		t.Errorf("GroupLocations =\n%+v\nwant\n%+v", locations, want)
+	}
+	// the second page reaches since, so paging stops there
+	if got := h.requestCount(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestGroupStats(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h := newFakeHistory(150, start)
+	h.messages[99].FavoritedBy = []string{"u1"} // created exactly at since
+	h.messages[100].FavoritedBy = []string{"u0"}
+	h.messages[119].FavoritedBy = []string{"u1", "u2"}
+	h.messages[149].FavoritedBy = []string{"u1"}
+	c := newTestClient(t, h)
+
+	stats, err := c.GroupStats(context.Background(), "g1", start.Add(99*time.Minute))
+	if err != nil {
+		t.Fatalf("GroupStats: %v", err)
+	}
+
+	// messages 101 to 150 are after since
+	want := GroupStatsResult{
+		TotalMessages: 50,
+		MessagesBy:    map[string]int{"u0": 17, "u1": 16, "u2": 17},
+		LikesGiven:    map[string]int{"u0": 1, "u1": 2, "u2": 1},
+		LikesReceived: map[string]int{"u0": 3, "u1": 0, "u2": 1},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("GroupStats =\n%+v\nwant\n%+v", stats, want)
+	}
+}
+
+func TestGroupStatsEmpty(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h := newFakeHistory(10, start)
+	c := newTestClient(t, h)
+
+	stats, err := c.GroupStats(context.Background(), "g1", start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GroupStats: %v", err)
+	}
+	if stats.TotalMessages != 0 || len(stats.MessagesBy) != 0 || len(stats.LikesGiven) != 0 || len(stats.LikesReceived) != 0 {
+		t.Errorf("GroupStats = %+v, want no activity", stats)
+	}
+}
+
+func TestGroupStatsError(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h := newFakeHistory(150, start)
+	h.failOn = 2
+	c := newTestClient(t, h)
+
+	_, err := c.GroupStats(context.Background(), "g1", start)
+	if !errors.Is(err, ErrInternalServerError) {
+		t.Errorf("GroupStats error = %v, want ErrInternalServerError", err)
+	}
+}
+
+func TestInactiveMembers(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h := newFakeHistory(150, start)
+	members := []*Member{
+		{ID: "m4", UserID: "u9"},
+		{ID: "m3", UserID: "u0"},
+		{ID: "m1", UserID: "u1"},
+		{ID: "m2", UserID: "u2"},
+	}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/groups/g1" {
+			writeEnvelope(w, http.StatusOK, Group{ID: "g1", Members: members})
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+
+	// message 148 from u1 is created exactly at since, so only u2 (149) and
+	// u0 (150) are active
+	inactive, err := c.InactiveMembers(context.Background(), "g1", start.Add(147*time.Minute))
+	if err != nil {
+		t.Fatalf("InactiveMembers: %v", err)
+	}
+
+	want := []Member{*members[2], *members[0]}
+	if !reflect.DeepEqual(inactive, want) {
+		t.Errorf("InactiveMembers = %+v, want %+v", inactive, want)
+	}
+	if got := h.requestCount(); got != 1 {
+		t.Errorf("message requests = %d, want 1", got)
+	}
+}
+
+func TestInactiveMembersAllActive(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h := newFakeHistory(150, start)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/groups/g1" {
+			writeEnvelope(w, http.StatusOK, Group{ID: "g1", Members: []*Member{{ID: "m1", UserID: "u1"}}})
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+
+	inactive, err := c.InactiveMembers(context.Background(), "g1", start)
+	if err != nil {
+		t.Fatalf("InactiveMembers: %v", err)
+	}
+	if len(inactive) != 0 {
+		t.Errorf("InactiveMembers = %+v, want none", inactive)
+	}
+}
@@ -0,0 +1,229 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// MessageStream delivers new messages from a group as they arrive.
+type MessageStream struct {
+	messages chan *Message
+	cancel   context.CancelFunc
+	done     chan struct{}
+	err      error
+}
+
+// DefaultPollInterval is the time between polls of a stream whose interval is
+// not positive.
+const DefaultPollInterval = 5 * time.Second
+
+// PollOptions configures PollNewMessages.
+type PollOptions struct {
+	// MinInterval is the time between polls while the group is active.
+	// Defaults to DefaultPollInterval.
+	MinInterval time.Duration
+
+	// MaxInterval caps the time between polls while the group is quiet.
+	// If it is not greater than MinInterval, polls are made every
+	// MinInterval.
+	MaxInterval time.Duration
+
+	// IdlePolls is the number of consecutive polls without new messages
+	// after which the interval starts growing. Defaults to 3.
+	IdlePolls int
+
+	// Backoff is the factor the interval grows by after each further idle
+	// poll. Defaults to 2.
+	Backoff float64
+
+	// newTimer makes the timer polls wait on; tests replace it.
+	newTimer func(d time.Duration) pollTimer
+}
+
+// pollTimer is the part of a time.Timer a stream uses.
+type pollTimer interface {
+	C() <-chan time.Time
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// realTimer is a pollTimer backed by a time.Timer.
+type realTimer struct {
+	*time.Timer
+}
+
+func newRealTimer(d time.Duration) pollTimer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// PollNewMessages polls a group and delivers messages created after the stream
+// started, oldest first. Polls are made every MinInterval until IdlePolls
+// consecutive polls find nothing, then the interval grows by Backoff up to
+// MaxInterval, returning to MinInterval as soon as a message arrives.
+//
+// The stream runs until ctx is done or Close is called; either way, its
+// goroutine exits and the Messages channel is closed.
+func (c *Client) PollNewMessages(ctx context.Context, groupID string, opts PollOptions) *MessageStream {
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = DefaultPollInterval
+	}
+	if opts.IdlePolls <= 0 {
+		opts.IdlePolls = 3
+	}
+	if opts.Backoff <= 1 {
+		opts.Backoff = 2
+	}
+	if opts.newTimer == nil {
+		opts.newTimer = newRealTimer
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &MessageStream{
+		messages: make(chan *Message),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go s.run(ctx, c, groupID, opts)
+
+	return s
+}
+
+// StreamMessages polls a group every interval (DefaultPollInterval if it is
+// not positive) and delivers messages created after the stream started,
+// oldest first. It is PollNewMessages without backoff.
+func (c *Client) StreamMessages(ctx context.Context, groupID string, interval time.Duration) *MessageStream {
+	return c.PollNewMessages(ctx, groupID, PollOptions{MinInterval: interval})
+}
+
+// Messages returns the channel messages are delivered on. It is closed when
+// the stream stops.
+func (s *MessageStream) Messages() <-chan *Message {
+	return s.messages
+}
+
+// Close stops the stream. It returns once the stream's goroutine has exited
+// and the Messages channel is closed, with the error that stopped the stream,
+// if any. Close may be called more than once.
+func (s *MessageStream) Close() error {
+	s.cancel()
+	<-s.done
+	return s.err
+}
+
+// Done returns a channel that is closed when the stream stops.
+func (s *MessageStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that stopped the stream, once it has stopped.
+func (s *MessageStream) Err() error {
+	<-s.done
+	return s.err
+}
+
+// nextInterval returns the interval to wait after a poll, given the interval
+// waited before it and the number of consecutive idle polls including it.
+func (o PollOptions) nextInterval(interval time.Duration, idle int) time.Duration {
+	if idle == 0 || o.MaxInterval <= o.MinInterval {
+		return o.MinInterval
+	}
+	if idle < o.IdlePolls {
+		return interval
+	}
+
+	next := time.Duration(float64(interval) * o.Backoff)
+	if next > o.MaxInterval {
+		next = o.MaxInterval
+	}
+	return next
+}
+
+func (s *MessageStream) run(ctx context.Context, c *Client, groupID string, opts PollOptions) {
+	defer close(s.done)
+	defer close(s.messages)
+
+	// start after the newest message
+	var afterID string
+	latest, err := c.LatestMessage(ctx, groupID)
+	switch {
+	case err == nil:
+		afterID = latest.ID
+	case !errors.Is(err, ErrNoMessages):
+		s.stop(ctx, err)
+		return
+	}
+
+	interval := opts.MinInterval
+	timer := opts.newTimer(interval)
+	defer timer.Stop()
+
+	var idle int
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+		}
+
+		// deliver everything after the last delivered message
+		idle++
+		for {
+			messages, err := pollAfter(ctx, c, groupID, afterID)
+			if err != nil {
+				s.stop(ctx, err)
+				return
+			}
+			if len(messages) == 0 {
+				break
+			}
+
+			for _, message := range messages {
+				select {
+				case s.messages <- message:
+				case <-ctx.Done():
+					return
+				}
+				afterID = message.ID
+			}
+			idle = 0
+		}
+
+		interval = opts.nextInterval(interval, idle)
+		timer.Reset(interval)
+	}
+}
+
+// pollAfter retrieves up to a page of messages created after afterID, oldest
+// first. If afterID is empty, the group had no messages when the stream
+// started, so its newest messages are all new.
+func pollAfter(ctx context.Context, c *Client, groupID, afterID string) ([]*Message, error) {
+	messages, err := c.GetMessagesWithContext(ctx, groupID, "100", "", "", afterID)
+	if err != nil {
+		if errors.Is(err, ErrNotModified) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// without after_id, messages come newest first
+	if afterID == "" {
+		reverseMessages(messages.Messages)
+	}
+
+	return messages.Messages, nil
+}
+
+// stop records err as the reason the stream stopped, unless it was stopped
+// through its context.
+func (s *MessageStream) stop(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	s.err = err
+}
@@ -0,0 +1,178 @@
+package groupme
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// fakeTimer is a pollTimer that fires when the test sends on c, and reports
+// every interval it is set to on resets.
+type fakeTimer struct {
+	c      chan time.Time
+	resets chan time.Duration
+}
+
+func newFakeTimer() *fakeTimer {
+	return &fakeTimer{
+		c:      make(chan time.Time),
+		resets: make(chan time.Duration, 16),
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+func (t *fakeTimer) Stop() bool          { return true }
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.resets <- d
+	return true
+}
+
+// factory returns a newTimer func handing out t.
+func (t *fakeTimer) factory() func(time.Duration) pollTimer {
+	return func(d time.Duration) pollTimer {
+		t.resets <- d
+		return t
+	}
+}
+
+// tick fires the timer and returns the interval the stream waits next.
+func (t *fakeTimer) tick(tb testing.TB) time.Duration {
+	tb.Helper()
+	t.c <- time.Now()
+	return t.next(tb)
+}
+
+// next returns the next interval the stream sets.
+func (t *fakeTimer) next(tb testing.TB) time.Duration {
+	tb.Helper()
+	select {
+	case d := <-t.resets:
+		return d
+	case <-time.After(5 * time.Second):
+		tb.Fatal("timer not reset")
+		return 0
+	}
+}
+
+func TestPollNewMessagesBackoff(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h := newFakeHistory(5, start)
+	c := newTestClient(t, h)
+	ft := newFakeTimer()
+
+	s := c.PollNewMessages(context.Background(), "g1", PollOptions{
+		MinInterval: time.Second,
+		MaxInterval: 8 * time.Second,
+		IdlePolls:   2,
+		newTimer:    ft.factory(),
+	})
+	defer s.Close()
+
+	if got := ft.next(t); got != time.Second {
+		t.Fatalf("first interval = %s, want 1s", got)
+	}
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second} {
+		if got := ft.tick(t); got != want {
+			t.Fatalf("interval after idle poll %d = %s, want %s", i+1, got, want)
+		}
+	}
+
+	// a new message resets the interval
+	h.add("new", start.Add(time.Hour))
+	ft.c <- time.Now()
+	select {
+	case m := <-s.Messages():
+		if m.ID != "6" {
+			t.Errorf("delivered message %s, want 6", m.ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not delivered")
+	}
+	if got := ft.next(t); got != time.Second {
+		t.Errorf("interval after a message = %s, want 1s", got)
+	}
+}
+
+func TestPollNewMessagesDefaultInterval(t *testing.T) {
+	h := newFakeHistory(1, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+	ft := newFakeTimer()
+
+	s := c.PollNewMessages(context.Background(), "g1", PollOptions{newTimer: ft.factory()})
+	defer s.Close()
+
+	if got := ft.next(t); got != DefaultPollInterval {
+		t.Fatalf("first interval = %s, want %s", got, DefaultPollInterval)
+	}
+	for i := 0; i < 3; i++ {
+		if got := ft.tick(t); got != DefaultPollInterval {
+			t.Fatalf("interval after poll %d = %s, want %s", i+1, got, DefaultPollInterval)
+		}
+	}
+}
+
+func TestStreamMessagesZeroInterval(t *testing.T) {
+	h := newFakeHistory(1, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+
+	s := c.StreamMessages(context.Background(), "g1", 0)
+	time.Sleep(100 * time.Millisecond)
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// only the request for the newest message, with no polls yet
+	if got := h.requestCount(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestStreamCancelDoesNotLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	start := time.Unix(1700000000, 0)
+	h := newFakeHistory(1, start)
+	srv := httptest.NewServer(h)
+	transport := &http.Transport{}
+	c, err := NewClient("test-token", WithBaseURL(srv.URL), WithVersion(""), WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ft := newFakeTimer()
+	ctx, cancel := context.WithCancel(context.Background())
+	s := c.PollNewMessages(ctx, "g1", PollOptions{MinInterval: time.Second, newTimer: ft.factory()})
+	ft.next(t)
+
+	// leave the stream blocked delivering a message nobody reads
+	h.add("unread", start.Add(time.Hour))
+	ft.c <- time.Now()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-s.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream goroutine still running after cancel")
+	}
+	if _, ok := <-s.Messages(); ok {
+		t.Error("Messages channel not closed")
+	}
+	if err := s.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil after cancel", err)
+	}
+
+	transport.CloseIdleConnections()
+	srv.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines = %d, want at most %d", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,187 @@
+package groupme
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+)
+
+// SyncMessages retrieves the messages in a group created after the message
+// sinceID, in chronological order, and the cursor to pass next time: the ID
+// of the newest message seen. If sinceID is empty, the whole history is
+// retrieved. If nothing is new, the cursor is sinceID.
+func (c *Client) SyncMessages(ctx context.Context, groupID, sinceID string) ([]*Message, string, error) {
+	var history []*Message
+
+	if sinceID == "" {
+		all, _, err := c.AllMessagesFrom(ctx, groupID, "")
+		if err != nil {
+			return nil, "", err
+		}
+		reverseMessages(all)
+		history = all
+	} else {
+		err := c.pagesAfter(ctx, groupID, sinceID, func(page []*Message) error {
+			history = append(history, page...)
+			return nil
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	cursor := sinceID
+	if len(history) > 0 {
+		cursor = history[len(history)-1].ID
+	}
+
+	return history, cursor, nil
+}
+
+// pagesAfter calls fn with each page of the messages in a group created after
+// the message afterID, oldest first, stopping at the first error fn returns.
+func (c *Client) pagesAfter(ctx context.Context, groupID, afterID string, fn func(page []*Message) error) error {
+	for {
+		messages, err := c.GetMessagesWithContext(ctx, groupID, "100", "", "", afterID)
+		if err != nil {
+			if errors.Is(err, ErrNotModified) {
+				return nil
+			}
+			return err
+		}
+		if len(messages.Messages) == 0 {
+			return nil
+		}
+
+		if err := fn(messages.Messages); err != nil {
+			return err
+		}
+		afterID = messages.Messages[len(messages.Messages)-1].ID
+	}
+}
+
+// CursorStore persists SyncMessages cursors by group ID.
+type CursorStore interface {
+	// Load returns the cursor saved for a group, or "" if there is none.
+	Load(groupID string) (string, error)
+
+	// Save saves the cursor for a group.
+	Save(groupID, id string) error
+}
+
+// SyncMessagesWithStore is SyncMessages with its cursor loaded from and saved
+// to store. The cursor is only saved once the new messages are retrieved.
+func (c *Client) SyncMessagesWithStore(ctx context.Context, store CursorStore, groupID string) ([]*Message, error) {
+	sinceID, err := store.Load(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, cursor, err := c.SyncMessages(ctx, groupID, sinceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor != sinceID {
+		if err := store.Save(groupID, cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	return messages, nil
+}
+
+// MemoryCursorStore is a CursorStore held in memory. It is safe for
+// concurrent use.
+type MemoryCursorStore struct {
+	mu      sync.Mutex
+	cursors map[string]string
+}
+
+// NewMemoryCursorStore returns an empty MemoryCursorStore.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: map[string]string{}}
+}
+
+// Load returns the cursor saved for a group.
+func (s *MemoryCursorStore) Load(groupID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursors[groupID], nil
+}
+
+// Save saves the cursor for a group.
+func (s *MemoryCursorStore) Save(groupID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[groupID] = id
+	return nil
+}
+
+// FileCursorStore is a CursorStore kept in a JSON file. It is safe for
+// concurrent use within a process.
+type FileCursorStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCursorStore returns a FileCursorStore kept at path. The file is
+// created on the first Save.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+// Load returns the cursor saved for a group.
+func (s *FileCursorStore) Load(groupID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	return cursors[groupID], nil
+}
+
+// Save saves the cursor for a group.
+func (s *FileCursorStore) Save(groupID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return err
+	}
+	cursors[groupID] = id
+
+	buf, err := json.Marshal(cursors)
+	if err != nil {
+		return err
+	}
+
+	// write atomically so a crash cannot truncate the file
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func (s *FileCursorStore) read() (map[string]string, error) {
+	cursors := map[string]string{}
+
+	buf, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cursors, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(buf, &cursors); err != nil {
+		return nil, err
+	}
+	return cursors, nil
+}
@@ -0,0 +1,150 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSyncMessages(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h := newFakeHistory(150, start)
+	c := newTestClient(t, h)
+	ctx := context.Background()
+
+	// from scratch: the whole history, oldest first
+	messages, cursor, err := c.SyncMessages(ctx, "g1", "")
+	if err != nil {
+		t.Fatalf("SyncMessages: %v", err)
+	}
+	if len(messages) != 150 || messages[0].ID != "1" || messages[149].ID != "150" || cursor != "150" {
+		t.Fatalf("full sync = %d messages, cursor %q", len(messages), cursor)
+	}
+
+	// nothing new: the cursor stays
+	messages, cursor, err = c.SyncMessages(ctx, "g1", cursor)
+	if err != nil || len(messages) != 0 || cursor != "150" {
+		t.Fatalf("empty sync = %d messages, %q, %v", len(messages), cursor, err)
+	}
+
+	h.add("new 1", start.Add(time.Hour*3))
+	h.add("new 2", start.Add(time.Hour*3))
+	messages, cursor, err = c.SyncMessages(ctx, "g1", cursor)
+	if err != nil {
+		t.Fatalf("SyncMessages: %v", err)
+	}
+	if got := messageIDs(messages); !reflect.DeepEqual(got, []string{"151", "152"}) || cursor != "152" {
+		t.Errorf("delta = %v, cursor %q; want [151 152], 152", got, cursor)
+	}
+}
+
+func TestSyncMessagesWithStoreResume(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	c := newTestClient(t, h)
+	store := NewMemoryCursorStore()
+	store.Save("g1", "20")
+
+	messages, err := c.SyncMessagesWithStore(context.Background(), store, "g1")
+	if err != nil {
+		t.Fatalf("SyncMessagesWithStore: %v", err)
+	}
+
+	var want []string
+	for i := 21; i <= 250; i++ {
+		want = append(want, strconv.Itoa(i))
+	}
+	if got := messageIDs(messages); !reflect.DeepEqual(got, want) {
+		t.Errorf("resumed with %d messages %v, want 21 to 250", len(got), got)
+	}
+	if cursor, _ := store.Load("g1"); cursor != "250" {
+		t.Errorf("cursor = %q, want 250", cursor)
+	}
+}
+
+func TestSyncMessagesWithStoreFailedPage(t *testing.T) {
+	h := newFakeHistory(250, time.Unix(1700000000, 0))
+	// the first page arrives, the second fails
+	h.failOn = 2
+	c := newTestClient(t, h)
+	store := NewMemoryCursorStore()
+	store.Save("g1", "20")
+
+	if _, err := c.SyncMessagesWithStore(context.Background(), store, "g1"); !errors.Is(err, ErrInternalServerError) {
+		t.Fatalf("err = %v, want ErrInternalServerError", err)
+	}
+	if cursor, _ := store.Load("g1"); cursor != "20" {
+		t.Errorf("cursor = %q, want 20 kept", cursor)
+	}
+}
+
+func TestFileCursorStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursors.json")
+	store := NewFileCursorStore(path)
+
+	if cursor, err := store.Load("g1"); err != nil || cursor != "" {
+		t.Fatalf("Load before any Save = %q, %v; want \"\", nil", cursor, err)
+	}
+	if err := store.Save("g1", "100"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Save("g2", "7"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// the temporary file is renamed into place
+	if _, err := os.Stat(path + ".tmp"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	// a new store, as after a restart, sees both cursors
+	reloaded := NewFileCursorStore(path)
+	for group, want := range map[string]string{"g1": "100", "g2": "7"} {
+		if cursor, err := reloaded.Load(group); err != nil || cursor != want {
+			t.Errorf("Load(%s) = %q, %v; want %q", group, cursor, err, want)
+		}
+	}
+}
+
+func TestFileCursorStoreCrash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursors.json")
+	if err := NewFileCursorStore(path).Save("g1", "100"); err != nil {
+		t.Fatal(err)
+	}
+
+	// a crash part way through the next Save leaves a torn temporary file
+	if err := os.WriteFile(path+".tmp", []byte(`{"g1":"2`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileCursorStore(path)
+	if cursor, err := store.Load("g1"); err != nil || cursor != "100" {
+		t.Fatalf("Load after crash = %q, %v; want the last saved 100", cursor, err)
+	}
+	if err := store.Save("g1", "200"); err != nil {
+		t.Fatalf("Save after crash: %v", err)
+	}
+	if cursor, err := NewFileCursorStore(path).Load("g1"); err != nil || cursor != "200" {
+		t.Errorf("Load = %q, %v; want 200", cursor, err)
+	}
+}
+
+func TestFileCursorStoreCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursors.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileCursorStore(path)
+	if _, err := store.Load("g1"); err == nil {
+		t.Error("Load of a corrupt file succeeded")
+	}
+	// a corrupt file is not overwritten, losing every other cursor
+	if err := store.Save("g1", "1"); err == nil {
+		t.Error("Save over a corrupt file succeeded")
+	}
+}
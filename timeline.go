@@ -0,0 +1,74 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Timeline retrieves the newest perGroup messages from every group the
+// authenticated user is in, fetching up to concurrency groups at once, and
+// returns them merged newest first. A group that cannot be read does not stop
+// the others: its messages are left out and its error is joined into the
+// returned error, alongside the messages that were retrieved.
+func (c *Client) Timeline(ctx context.Context, perGroup int, concurrency int) ([]*Message, error) {
+	if perGroup <= 0 {
+		return nil, fmt.Errorf("groupme: Timeline: perGroup must be positive, got %d", perGroup)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var groups []*Group
+	it := c.IterateGroups()
+	for it.Next(ctx) {
+		groups = append(groups, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		timeline []*Message
+		errs     []error
+	)
+	sem := make(chan struct{}, concurrency)
+loop:
+	for _, group := range groups {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errs = append(errs, ctx.Err())
+			mu.Unlock()
+			break loop
+		}
+
+		wg.Add(1)
+		go func(group *Group) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			messages, err := c.RecentMessages(ctx, group.ID, perGroup)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("group %s: %w", group.ID, err))
+				return
+			}
+			timeline = append(timeline, messages...)
+		}(group)
+	}
+	wg.Wait()
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].CreatedAt > timeline[j].CreatedAt
+	})
+
+	return timeline, errors.Join(errs...)
+}
@@ -0,0 +1,74 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// timelineServer serves a list of groups and the history of each.
+type timelineServer struct {
+	groups    []*Group
+	histories map[string]*fakeHistory
+}
+
+func (s *timelineServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/groups" {
+		if r.URL.Query().Get("page") != "1" {
+			writeEnvelope(w, http.StatusOK, []*Group{})
+			return
+		}
+		writeEnvelope(w, http.StatusOK, s.groups)
+		return
+	}
+
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/groups/"), "/messages")
+	h, ok := s.histories[id]
+	if !ok {
+		writeMetaError(w, http.StatusForbidden)
+		return
+	}
+	h.ServeHTTP(w, r)
+}
+
+func TestTimeline(t *testing.T) {
+	a := newFakeHistory(5, time.Unix(1700000000, 0))
+	b := newFakeHistory(5, time.Unix(1700000030, 0))
+	s := &timelineServer{
+		groups:    []*Group{{ID: "a"}, {ID: "b"}, {ID: "empty"}, {ID: "left"}},
+		histories: map[string]*fakeHistory{"a": a, "b": b, "empty": newFakeHistory(0, time.Now())},
+	}
+	c := newTestClient(t, s)
+
+	timeline, err := c.Timeline(context.Background(), 2, 2)
+	if !errors.Is(err, ErrNotMember) || !strings.Contains(err.Error(), "group left") {
+		t.Errorf("err = %v, want ErrNotMember for group left", err)
+	}
+	if len(timeline) != 4 {
+		t.Fatalf("got %d messages, want 4", len(timeline))
+	}
+	for i := 1; i < len(timeline); i++ {
+		if timeline[i].CreatedAt > timeline[i-1].CreatedAt {
+			t.Fatalf("timeline not newest first at %d", i)
+		}
+	}
+	// b's messages are 30 seconds newer than a's
+	if got := timeline[0]; got.CreatedAt != b.messages[4].CreatedAt {
+		t.Errorf("newest = %+v, want b's message 5", got)
+	}
+}
+
+func TestTimelineRejectsPerGroup(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+
+	for _, perGroup := range []int{0, -5} {
+		if _, err := c.Timeline(context.Background(), perGroup, 1); err == nil {
+			t.Errorf("Timeline with perGroup %d: want error", perGroup)
+		}
+	}
+}
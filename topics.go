@@ -0,0 +1,46 @@
+package groupme
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Topic is a subgroup of a GroupMe group with its own message stream.
+type Topic struct {
+	ID          string `json:"id"`
+	GroupID     string `json:"parent_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url"`
+	CreatedAt   int    `json:"created_at"`
+	UpdatedAt   int    `json:"updated_at"`
+}
+
+// ListTopics retrieves the topics of a group. GroupMe does not document its
+// topic endpoints, so they may change without notice.
+func (c *Client) ListTopics(groupID string) ([]Topic, error) {
+	return c.ListTopicsWithContext(context.Background(), groupID)
+}
+
+// ListTopicsWithContext is ListTopics with a context.
+func (c *Client) ListTopicsWithContext(ctx context.Context, groupID string) ([]Topic, error) {
+	var topics []Topic
+	err := c.doRequest(ctx, "topics.list", http.MethodGet, fmt.Sprintf("/groups/%s/subgroups", groupID), nil, nil, &topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+// GetTopicMessages retrieves messages older than beforeID (or the newest
+// messages if beforeID is empty) from a topic.
+func (c *Client) GetTopicMessages(groupID, topicID string, beforeID string) (GetMessagesResponse, error) {
+	return c.GetTopicMessagesWithContext(context.Background(), groupID, topicID, beforeID)
+}
+
+// GetTopicMessagesWithContext is GetTopicMessages with a context.
+func (c *Client) GetTopicMessagesWithContext(ctx context.Context, groupID, topicID string, beforeID string) (GetMessagesResponse, error) {
+	return c.getMessagesAt(ctx, "topics.messages", fmt.Sprintf("/groups/%s/subgroups/%s/messages", groupID, topicID), "", beforeID, "", "")
+}
@@ -0,0 +1,91 @@
+package groupme
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Tracer traces the requests a Client makes, to the API and to GroupMe's
+// image and powerup services, and a Bot's posts. It is shaped to be backed by
+// OpenTelemetry, without this package depending on it: StartRequest starts a
+// span named after the endpoint, setting RequestInfo as its attributes, and
+// End sets the RequestResult and ends it. The context returned by
+// StartRequest is used for the request, so an instrumented HTTPClient
+// transport sees the span as its parent.
+type Tracer interface {
+	StartRequest(ctx context.Context, info RequestInfo) (context.Context, RequestSpan)
+}
+
+// RequestSpan is a traced request.
+type RequestSpan interface {
+	End(result RequestResult)
+}
+
+// RequestInfo describes a request being traced.
+type RequestInfo struct {
+	// a stable name for the call, as given to Metrics
+	Endpoint string
+	Method   string
+
+	// the group or conversation the request concerns, if any
+	GroupID string
+}
+
+// RequestResult is the outcome of a traced request, after any retries.
+type RequestResult struct {
+	// 0 if no response was received
+	StatusCode int
+
+	Retries int
+	Err     error
+}
+
+// WithTracer sets the Client's Tracer.
+func WithTracer(t Tracer) Option {
+	return func(c *Client) {
+		c.Tracer = t
+	}
+}
+
+// startSpan starts a span for a request with tracer, which may be nil. The
+// returned span is never nil.
+func startSpan(ctx context.Context, tracer Tracer, info RequestInfo) (context.Context, RequestSpan) {
+	if tracer == nil {
+		return ctx, noSpan{}
+	}
+	return tracer.StartRequest(ctx, info)
+}
+
+// noSpan is the RequestSpan of untraced requests.
+type noSpan struct{}
+
+func (noSpan) End(RequestResult) {}
+
+// instrument makes a request with do, tracing it with the Client's Tracer and
+// then reporting it to its Metrics and Logger. do returns the status of the
+// last response and the number of retries.
+func (c *Client) instrument(ctx context.Context, info RequestInfo, do func(ctx context.Context) (int, int, error)) error {
+	ctx, span := startSpan(ctx, c.Tracer, info)
+
+	start := time.Now()
+	status, retries, err := do(ctx)
+	c.observe(info.Endpoint, info.Method, status, time.Since(start), err)
+	span.End(RequestResult{StatusCode: status, Retries: retries, Err: err})
+
+	return err
+}
+
+// groupIDOf returns the group or conversation ID in an API route, or "".
+func groupIDOf(route string) string {
+	parts := strings.Split(strings.Trim(route, "/"), "/")
+	if len(parts) < 2 || parts[1] == "search" {
+		return ""
+	}
+
+	switch parts[0] {
+	case "groups", "conversations", "messages", "poll":
+		return parts[1]
+	}
+	return ""
+}
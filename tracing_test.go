@@ -0,0 +1,166 @@
+package groupme
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// spanKey marks the contexts of spans started by recordingTracer.
+type spanKey struct{}
+
+// recordingTracer records the spans started with it.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	info   RequestInfo
+	result *RequestResult
+}
+
+func (t *recordingTracer) StartRequest(ctx context.Context, info RequestInfo) (context.Context, RequestSpan) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := &recordedSpan{info: info}
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, spanKey{}, info.Endpoint), s
+}
+
+func (s *recordedSpan) End(result RequestResult) {
+	s.result = &result
+}
+
+// spanTransport checks that requests are sent with a span's context.
+type spanTransport struct {
+	t *testing.T
+}
+
+func (st spanTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Context().Value(spanKey{}) == nil {
+		st.t.Errorf("%s %s sent without the span's context", req.Method, req.URL)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestTracerRetries(t *testing.T) {
+	var requests int
+	tracer := &recordingTracer{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			writeMetaError(w, http.StatusServiceUnavailable)
+			return
+		}
+		writeEnvelope(w, http.StatusOK, nil)
+	}), WithTracer(tracer), WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		WithHTTPClient(&http.Client{Transport: spanTransport{t}}))
+
+	if err := c.LikeMessage("g1", "m1"); err != nil {
+		t.Fatalf("LikeMessage: %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(tracer.spans))
+	}
+	s := tracer.spans[0]
+	if want := (RequestInfo{Endpoint: "messages.like", Method: http.MethodPost, GroupID: "g1"}); s.info != want {
+		t.Errorf("info = %+v, want %+v", s.info, want)
+	}
+	if want := (RequestResult{StatusCode: http.StatusOK, Retries: 1}); s.result == nil || *s.result != want {
+		t.Errorf("result = %+v, want %+v", s.result, want)
+	}
+}
+
+func TestTracerOutsideAPI(t *testing.T) {
+	tracer := &recordingTracer{}
+	c := newRedirectedClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Host {
+		case "image.groupme.com":
+			io.WriteString(w, `{"payload":{"url":"https://i.groupme.com/1","picture_url":"https://i.groupme.com/1.png"}}`)
+		case "i.groupme.com":
+			io.WriteString(w, "PNGDATA")
+		case "powerup.groupme.com":
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			t.Errorf("request to %s%s", r.Host, r.URL.Path)
+		}
+	}), WithTracer(tracer))
+	ctx := context.Background()
+
+	if _, err := c.UploadImage(ctx, strings.NewReader("PNGDATA"), "image/png"); err != nil {
+		t.Fatalf("UploadImage: %v", err)
+	}
+	if _, _, err := c.DownloadAttachment(ctx, "https://i.groupme.com/1"); err != nil {
+		t.Fatalf("DownloadAttachment: %v", err)
+	}
+	if _, _, err := c.ListPowerupPacksIfChanged(ctx, `"v1"`); err == nil {
+		t.Fatal("ListPowerupPacksIfChanged: want a 304 error")
+	}
+	if _, _, err := c.DownloadAttachment(ctx, "https://example.com/p.png"); !errors.Is(err, ErrNotGroupMeMedia) {
+		t.Fatalf("DownloadAttachment: err = %v, want ErrNotGroupMeMedia", err)
+	}
+
+	var got []RequestInfo
+	var statuses []int
+	for _, s := range tracer.spans {
+		got = append(got, s.info)
+		if s.result == nil {
+			t.Fatalf("span %s not ended", s.info.Endpoint)
+		}
+		statuses = append(statuses, s.result.StatusCode)
+	}
+	want := []RequestInfo{
+		{Endpoint: "images.upload", Method: http.MethodPost},
+		{Endpoint: "images.download", Method: http.MethodGet},
+		{Endpoint: "powerups.list", Method: http.MethodGet},
+		{Endpoint: "images.download", Method: http.MethodGet},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("spans = %+v, want %+v", got, want)
+	}
+	if want := []int{200, 200, 304, 0}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+	if err := tracer.spans[3].result.Err; !errors.Is(err, ErrNotGroupMeMedia) {
+		t.Errorf("rejected download span err = %v", err)
+	}
+}
+
+func TestTracerBotPost(t *testing.T) {
+	s := &botServer{}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	tracer := &recordingTracer{}
+	bot := NewBot(srv.URL, "b1", "g1", "Alerts", "")
+	bot.HTTPClient = &http.Client{Transport: spanTransport{t}}
+	bot.Tracer = tracer
+
+	if err := bot.PostWithContext(context.Background(), strings.Repeat("x", MaxMessageLength+1), nil); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if len(s.posts) != 2 {
+		t.Errorf("got %d posts, want 2", len(s.posts))
+	}
+
+	// one span covers every part of the post
+	if len(tracer.spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if want := (RequestInfo{Endpoint: "bots.post", Method: http.MethodPost, GroupID: "g1"}); span.info != want {
+		t.Errorf("info = %+v, want %+v", span.info, want)
+	}
+	if want := (RequestResult{StatusCode: http.StatusAccepted}); span.result == nil || *span.result != want {
+		t.Errorf("result = %+v, want %+v", span.result, want)
+	}
+}
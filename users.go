@@ -0,0 +1,72 @@
+package groupme
+
+import (
+	"context"
+	"net/http"
+)
+
+// User is a GroupMe user.
+type User struct {
+	ID          string `json:"id"`
+	PhoneNumber string `json:"phone_number"`
+	ImageURL    string `json:"image_url"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	CreatedAt   int    `json:"created_at"`
+	UpdatedAt   int    `json:"updated_at"`
+	SMS         bool   `json:"sms"`
+}
+
+// GetMe retrieves the authenticated user.
+func (c *Client) GetMe(ctx context.Context) (*User, error) {
+	var user User
+	err := c.doRequest(ctx, "users.me", http.MethodGet, "/users/me", nil, nil, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UserRef is the display information of a user.
+type UserRef struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// ResolveUsers maps user IDs to display information. GroupMe has no batch user
+// lookup, so this is best-effort: users are found among the members of the
+// authenticated user's groups, and IDs not found there map to a UserRef with
+// only ID set.
+func (c *Client) ResolveUsers(ctx context.Context, userIDs []string) (map[string]UserRef, error) {
+	refs := make(map[string]UserRef, len(userIDs))
+	pending := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		pending[id] = true
+	}
+
+	groups := c.IterateGroups()
+	for len(pending) > 0 && groups.Next(ctx) {
+		for _, member := range groups.Item().Members {
+			if !pending[member.UserID] {
+				continue
+			}
+			refs[member.UserID] = UserRef{
+				ID:        member.UserID,
+				Name:      member.Nickname,
+				AvatarURL: member.ImageURL,
+			}
+			delete(pending, member.UserID)
+		}
+	}
+	if err := groups.Err(); err != nil {
+		return nil, err
+	}
+
+	for id := range pending {
+		refs[id] = UserRef{ID: id}
+	}
+
+	return refs, nil
+}